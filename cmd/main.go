@@ -3,33 +3,73 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
+	"github.com/AndrivA89/neo4j-go-playground/internal/app"
+	"github.com/AndrivA89/neo4j-go-playground/internal/config"
 	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
 	"github.com/AndrivA89/neo4j-go-playground/internal/repository"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
 	"github.com/AndrivA89/neo4j-go-playground/internal/ui"
-	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
 )
 
 func main() {
-	neo4jUri := "bolt://localhost:7687"
-	neo4jUsername := "neo4j"
-	neo4jPassword := "password"
-
-	driver, err := neo4j.NewDriverWithContext(neo4jUri, neo4j.BasicAuth(neo4jUsername, neo4jPassword, ""))
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatalf("Failed to create Neo4j driver: %v", err)
+		log.Fatalf("Failed to load config: %v", err)
 	}
-	defer func() {
-		if err = driver.Close(context.Background()); err != nil {
-			log.Printf("Error closing Neo4j driver: %v", err)
+
+	var openWorkspace func(ws domain.Workspace) (app.Services, error)
+
+	switch cfg.Backend {
+	case config.BackendMemory:
+		repo := memory.NewNodeRepository()
+		openWorkspace = func(ws domain.Workspace) (app.Services, error) {
+			scoped := repo.WithWorkspace(ws)
+			return app.NewServices(scoped), nil
+		}
+	default:
+		driver, err := neo4j.NewDriverWithContext(cfg.Neo4j.URI, neo4j.BasicAuth(cfg.Neo4j.Username, cfg.Neo4j.Password, ""))
+		if err != nil {
+			log.Fatalf("Failed to create Neo4j driver: %v", err)
 		}
-	}()
+		defer func() {
+			if err = driver.Close(context.Background()); err != nil {
+				log.Printf("Error closing Neo4j driver: %v", err)
+			}
+		}()
 
-	repo := repository.NewNodeRepository(driver)
-	nodeUseCase := usecase.NewNodeUseCase(repo)
+		repo := repository.NewNodeRepository(driver)
+		openWorkspace = func(ws domain.Workspace) (app.Services, error) {
+			scoped := repo.WithWorkspace(ws)
+
+			// Every workspace database needs its own schema.
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			if err := scoped.Migrate(ctx); err != nil {
+				return app.Services{}, err
+			}
+			return app.NewServices(scoped), nil
+		}
+	}
+
+	services, err := openWorkspace(cfg.ActiveWorkspace())
+	if err != nil {
+		log.Fatalf("Failed to open workspace %q: %v", cfg.Workspace, err)
+	}
+	nodeUseCase := services.Nodes
+
+	if len(cfg.Args) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := app.RunCommand(ctx, services, cfg.Args, os.Stdout); err != nil {
+			log.Fatalf("%s: %v", cfg.Args[0], err)
+		}
+		return
+	}
 
 	sampleNode1 := &domain.Node{
 		ID:      "1",
@@ -64,5 +104,12 @@ func main() {
 
 	nodes := []*domain.Node{sampleNode1, sampleNode2}
 
-	ui.ShowGraphUI(nodeUseCase, nodes, []ui.Edge{})
+	workspaces := app.Workspaces{
+		Names: cfg.WorkspaceNames(),
+		Switch: func(name string) (app.Services, error) {
+			return openWorkspace(cfg.WorkspaceByName(name))
+		},
+	}
+
+	ui.ShowGraphUI(services, nodes, []ui.Edge{}, workspaces, cfg.Theme)
 }
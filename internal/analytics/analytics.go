@@ -0,0 +1,240 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+const (
+	// Damping is the probability that the random surfer of PageRank follows a link
+	// instead of jumping to a random node.
+	Damping = 0.85
+
+	maxIterations = 100
+	tolerance     = 1e-9
+)
+
+// Degree counts the relationships of a node. A relationship of an undirected type counts
+// as incoming and outgoing at both ends; Total counts every relationship once.
+type Degree struct {
+	In, Out, Total int
+}
+
+// Report holds every metric of a snapshot.
+type Report struct {
+	Degrees  map[string]Degree
+	PageRank map[string]float64
+	// Ranking lists the nodes by descending PageRank.
+	Ranking []*domain.Node
+	// Components and Communities are ordered by descending size.
+	Components  [][]*domain.Node
+	Communities [][]*domain.Node
+	Orphans     []*domain.Node
+	DeadEnds    []*domain.Node
+}
+
+// Analyze computes every metric of the snapshot.
+func (s *Snapshot) Analyze() *Report {
+	r := &Report{
+		Degrees:     s.Degrees(),
+		PageRank:    s.PageRank(),
+		Components:  s.Components(),
+		Communities: s.Communities(),
+		Orphans:     s.Orphans(),
+		DeadEnds:    s.DeadEnds(),
+	}
+	r.Ranking = append([]*domain.Node(nil), s.Nodes...)
+	sort.SliceStable(r.Ranking, func(i, j int) bool {
+		return r.PageRank[r.Ranking[i].ID] > r.PageRank[r.Ranking[j].ID]
+	})
+	return r
+}
+
+// Degrees returns the degree of every node by id.
+func (s *Snapshot) Degrees() map[string]Degree {
+	degrees := make(map[string]Degree, len(s.Nodes))
+	for i, n := range s.Nodes {
+		degrees[n.ID] = Degree{In: len(s.in[i]), Out: len(s.out[i]), Total: s.total(i)}
+	}
+	return degrees
+}
+
+// total counts the relationships starting or ending at node i, self-loops once.
+func (s *Snapshot) total(i int) int {
+	total := len(s.adj[i])
+	for _, l := range s.out[i] {
+		if l.node == i {
+			total++
+		}
+	}
+	return total
+}
+
+// PageRank returns the weighted PageRank of every node by id; the scores add up to 1.
+// Nodes without outgoing links spread their score over all nodes.
+func (s *Snapshot) PageRank() map[string]float64 {
+	n := len(s.Nodes)
+	ranks := make(map[string]float64, n)
+	if n == 0 {
+		return ranks
+	}
+	outWeight := make([]float64, n)
+	for i := range s.Nodes {
+		for _, l := range s.out[i] {
+			outWeight[i] += l.weight
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for iter := 0; iter < maxIterations; iter++ {
+		var dangling float64
+		for i := range s.Nodes {
+			if outWeight[i] == 0 {
+				dangling += rank[i]
+			}
+		}
+		base := (1-Damping)/float64(n) + Damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i := range s.Nodes {
+			if outWeight[i] == 0 {
+				continue
+			}
+			for _, l := range s.out[i] {
+				next[l.node] += Damping * rank[i] * l.weight / outWeight[i]
+			}
+		}
+		var delta float64
+		for i := range rank {
+			delta += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if delta < tolerance {
+			break
+		}
+	}
+	for i, node := range s.Nodes {
+		ranks[node.ID] = rank[i]
+	}
+	return ranks
+}
+
+// Components returns the connected components, ignoring the direction of relationships.
+func (s *Snapshot) Components() [][]*domain.Node {
+	component := make([]int, len(s.Nodes))
+	for i := range component {
+		component[i] = -1
+	}
+	for start := range s.Nodes {
+		if component[start] >= 0 {
+			continue
+		}
+		component[start] = start
+		queue := []int{start}
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, l := range s.adj[i] {
+				if component[l.node] < 0 {
+					component[l.node] = start
+					queue = append(queue, l.node)
+				}
+			}
+		}
+	}
+	return s.groups(component)
+}
+
+// Communities detects groups of densely linked nodes by label propagation: every node
+// repeatedly takes the label carried by the largest weight of its neighbours. Nodes are
+// visited in snapshot order and ties keep the current label or take the smallest one, so the
+// result is deterministic.
+func (s *Snapshot) Communities() [][]*domain.Node {
+	label := make([]int, len(s.Nodes))
+	for i := range label {
+		label[i] = i
+	}
+	for iter := 0; iter < maxIterations; iter++ {
+		changed := false
+		for i := range s.Nodes {
+			if len(s.adj[i]) == 0 {
+				continue
+			}
+			weights := make(map[int]float64)
+			for _, l := range s.adj[i] {
+				// Relationships of weight 0 still connect their nodes.
+				weights[label[l.node]] += math.Max(l.weight, tolerance)
+			}
+			var maxWeight float64
+			for _, weight := range weights {
+				maxWeight = math.Max(maxWeight, weight)
+			}
+			best := label[i]
+			if weights[best] < maxWeight {
+				best = len(s.Nodes)
+				for candidate, weight := range weights {
+					if weight == maxWeight && candidate < best {
+						best = candidate
+					}
+				}
+			}
+			if best != label[i] {
+				label[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return s.groups(label)
+}
+
+// groups collects the nodes by group id, ordered by descending size and then by their
+// first node. The nodes of a group keep the snapshot order.
+func (s *Snapshot) groups(group []int) [][]*domain.Node {
+	byGroup := make(map[int][]*domain.Node)
+	var order []int
+	for i, node := range s.Nodes {
+		if _, ok := byGroup[group[i]]; !ok {
+			order = append(order, group[i])
+		}
+		byGroup[group[i]] = append(byGroup[group[i]], node)
+	}
+	result := make([][]*domain.Node, len(order))
+	for i, g := range order {
+		result[i] = byGroup[g]
+	}
+	sort.SliceStable(result, func(i, j int) bool { return len(result[i]) > len(result[j]) })
+	return result
+}
+
+// Orphans returns the nodes without any relationship.
+func (s *Snapshot) Orphans() []*domain.Node {
+	var orphans []*domain.Node
+	for i, node := range s.Nodes {
+		if s.total(i) == 0 {
+			orphans = append(orphans, node)
+		}
+	}
+	return orphans
+}
+
+// DeadEnds returns the nodes that are linked to but have no link leading away from them.
+// Relationships of undirected types lead both ways, so they never end in a dead end.
+func (s *Snapshot) DeadEnds() []*domain.Node {
+	var deadEnds []*domain.Node
+	for i, node := range s.Nodes {
+		if len(s.in[i]) > 0 && len(s.out[i]) == 0 {
+			deadEnds = append(deadEnds, node)
+		}
+	}
+	return deadEnds
+}
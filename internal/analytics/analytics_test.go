@@ -0,0 +1,82 @@
+package analytics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func TestAnalyze(t *testing.T) {
+	var nodes []*domain.Node
+	for _, id := range []string{"x", "h", "g", "f", "e", "d", "c", "b", "a"} {
+		nodes = append(nodes, &domain.Node{ID: id, Title: strings.ToUpper(id)})
+	}
+	rel := func(id, from, to string, relType domain.RelationType) *domain.Relationship {
+		return &domain.Relationship{ID: id, SourceID: from, TargetIDs: []string{to}, Type: relType}
+	}
+	bridge := rel("cd", "c", "d", domain.References)
+	bridge.Weight = 0.2
+	rels := []*domain.Relationship{
+		rel("ab", "a", "b", domain.RelatedTo),
+		rel("bc", "b", "c", domain.RelatedTo),
+		rel("ca", "c", "a", domain.RelatedTo),
+		bridge,
+		rel("de", "d", "e", domain.RelatedTo),
+		rel("ef", "e", "f", domain.RelatedTo),
+		rel("fd", "f", "d", domain.RelatedTo),
+		rel("gh", "g", "h", domain.HasPart),
+		rel("hg", "h", "g", domain.IsPartOf),
+		rel("cz", "c", "z", domain.References),
+	}
+	snapshot := analytics.NewSnapshot(nodes, rels, domain.NewTypeRegistry(nil, nil))
+	assert.Len(t, snapshot.Relationships, 8, "Inverse pairs and links to unknown nodes should be dropped")
+
+	report := snapshot.Analyze()
+	assert.Equal(t, analytics.Degree{In: 2, Out: 3, Total: 3}, report.Degrees["c"], "Undirected links should count both ways")
+	assert.Equal(t, analytics.Degree{In: 0, Out: 1, Total: 1}, report.Degrees["g"], "Inverse links should be counted once")
+	assert.Equal(t, analytics.Degree{}, report.Degrees["x"])
+
+	var sum float64
+	for _, rank := range report.PageRank {
+		sum += rank
+	}
+	assert.InDelta(t, 1, sum, 1e-6, "PageRank scores should add up to 1")
+	assert.Greater(t, report.PageRank["h"], report.PageRank["g"], "Linked-to nodes should rank higher")
+	assert.Greater(t, report.PageRank["d"], report.PageRank["e"], "The bridge should raise its target")
+	assert.Len(t, report.Ranking, len(nodes))
+	assert.Equal(t, report.PageRank[report.Ranking[0].ID], maxRank(report.PageRank), "The ranking should start with the highest score")
+
+	assert.Equal(t, [][]string{{"a", "b", "c", "d", "e", "f"}, {"g", "h"}, {"x"}}, ids(report.Components))
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h"}, {"x"}}, ids(report.Communities),
+		"The weak bridge should separate the triangles")
+	assert.Equal(t, [][]string{{"x"}}, ids([][]*domain.Node{report.Orphans}))
+	assert.Equal(t, [][]string{{"h"}}, ids([][]*domain.Node{report.DeadEnds}), "Undirected links should not end in a dead end")
+
+	empty := analytics.NewSnapshot(nil, nil, domain.NewTypeRegistry(nil, nil)).Analyze()
+	assert.Empty(t, empty.PageRank)
+	assert.Empty(t, empty.Components)
+}
+
+func maxRank(ranks map[string]float64) float64 {
+	var best float64
+	for _, rank := range ranks {
+		if rank > best {
+			best = rank
+		}
+	}
+	return best
+}
+
+func ids(groups [][]*domain.Node) [][]string {
+	result := make([][]string, len(groups))
+	for i, group := range groups {
+		for _, n := range group {
+			result[i] = append(result[i], n.ID)
+		}
+	}
+	return result
+}
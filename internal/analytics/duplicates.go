@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// Reasons why two nodes are reported as duplicates.
+const (
+	SameTitle      = "same title"
+	SimilarTitle   = "similar title"
+	SimilarContent = "similar content"
+)
+
+// Thresholds above which titles and contents count as similar.
+const (
+	titleSimilarity   = 0.85
+	contentSimilarity = 0.8
+)
+
+// Duplicate is a pair of nodes that probably describe the same thing. A is the older one.
+type Duplicate struct {
+	A, B   *domain.Node
+	Reason string
+	// Score lies between 0 and 1; a same title scores 1.
+	Score float64
+}
+
+// Duplicates returns the likely duplicate pairs, best first. Titles are compared after
+// normalisation (case, punctuation and plural s are ignored), then by edit distance; nodes
+// with different titles can still be duplicates by the TF-IDF similarity of their content.
+func (s *Snapshot) Duplicates() []Duplicate {
+	titles := make([]string, len(s.Nodes))
+	for i, n := range s.Nodes {
+		titles[i] = normalizeTitle(n.Title)
+	}
+	vectors := s.tfidf(func(n *domain.Node) string { return n.Content })
+
+	var duplicates []Duplicate
+	for i := range s.Nodes {
+		for j := i + 1; j < len(s.Nodes); j++ {
+			d := Duplicate{A: s.Nodes[i], B: s.Nodes[j]}
+			if titles[i] != "" && titles[i] == titles[j] {
+				d.Reason, d.Score = SameTitle, 1
+			} else if sim := editSimilarity(titles[i], titles[j]); sim >= titleSimilarity {
+				d.Reason, d.Score = SimilarTitle, sim
+			} else if strings.TrimSpace(d.A.Content) != "" && strings.TrimSpace(d.B.Content) != "" {
+				if sim := cosine(vectors[i], vectors[j]); sim >= contentSimilarity {
+					d.Reason, d.Score = SimilarContent, sim
+				}
+			}
+			if d.Reason != "" {
+				duplicates = append(duplicates, d)
+			}
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool { return duplicates[i].Score > duplicates[j].Score })
+	return duplicates
+}
+
+// normalizeTitle lower-cases the title, joins its words with single spaces and drops the
+// plural s of words longer than three letters.
+func normalizeTitle(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		if len([]rune(w)) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			words[i] = strings.TrimSuffix(w, "s")
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// editSimilarity is 1 minus the Levenshtein distance divided by the length of the longer
+// text, 0 if either text is empty.
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(max(len(ra), len(rb)))
+}
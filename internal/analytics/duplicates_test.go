@@ -0,0 +1,36 @@
+package analytics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func TestDuplicates(t *testing.T) {
+	nodes := []*domain.Node{
+		{ID: "1", Title: "Goroutines"},
+		{ID: "2", Title: "goroutine"},
+		{ID: "3", Title: "Go routines!"},
+		{ID: "4", Title: "Channel basics", Content: "Channels connect goroutines and pass typed values."},
+		{ID: "5", Title: "Pipes", Content: "Channels connect goroutines and pass typed values."},
+		{ID: "6", Title: "Bread", Content: "Flour, water and salt."},
+		{ID: "7", Title: "Class"},
+		{ID: "8", Title: "Glass"},
+	}
+	duplicates := analytics.NewSnapshot(nodes, nil, domain.NewTypeRegistry(nil, nil)).Duplicates()
+
+	type pair struct{ a, b, reason string }
+	var pairs []pair
+	for _, d := range duplicates {
+		pairs = append(pairs, pair{d.A.ID, d.B.ID, d.Reason})
+	}
+	assert.Equal(t, []pair{
+		{"1", "2", analytics.SameTitle},
+		{"4", "5", analytics.SimilarContent},
+		{"1", "3", analytics.SimilarTitle},
+		{"2", "3", analytics.SimilarTitle},
+	}, pairs, "Plural s, case and punctuation should be ignored and short titles should not be fuzzy matched")
+}
@@ -0,0 +1,119 @@
+// Package analytics computes metrics of a whole workspace, such as centrality, components
+// and communities, in memory on a snapshot loaded from any repository.
+package analytics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// Source is the part of a repository a snapshot is loaded from. It is implemented by
+// usecase.NodeRepository and usecase.Tx.
+type Source interface {
+	SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error)
+	ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error)
+}
+
+// Snapshot is a read-only copy of the nodes and relationships of a workspace.
+type Snapshot struct {
+	// Nodes are sorted by creation time and id, so that every metric is deterministic.
+	Nodes []*domain.Node
+	// Relationships are the relationships between Nodes. Of a relationship and its inverse
+	// only the one listed first is kept, so that a link is not counted twice.
+	Relationships []*domain.Relationship
+
+	index map[string]int
+	// out and in hold the links leaving and reaching each node; relationships of
+	// undirected types are stored in both directions.
+	out, in [][]link
+	// adj holds every neighbour once per relationship, ignoring the direction.
+	adj [][]link
+}
+
+// link is a step to the node with index node.
+type link struct {
+	node   int
+	weight float64
+}
+
+// Load reads every node and relationship from src. Call it within a transaction to get
+// a consistent snapshot.
+func Load(ctx context.Context, src Source, registry *domain.TypeRegistry) (*Snapshot, error) {
+	nodes, err := src.SearchNodes(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	rels, err := src.ListRelationships(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(nodes, rels, registry), nil
+}
+
+// NewSnapshot builds a snapshot of nodes and the relationships between them. registry
+// tells which relationship types are undirected and which are inverses of each other.
+func NewSnapshot(nodes []*domain.Node, rels []*domain.Relationship, registry *domain.TypeRegistry) *Snapshot {
+	s := &Snapshot{
+		Nodes: append([]*domain.Node(nil), nodes...),
+		index: make(map[string]int, len(nodes)),
+	}
+	sort.SliceStable(s.Nodes, func(i, j int) bool {
+		a, b := s.Nodes[i], s.Nodes[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	for i, n := range s.Nodes {
+		s.index[n.ID] = i
+	}
+	s.out = make([][]link, len(s.Nodes))
+	s.in = make([][]link, len(s.Nodes))
+	s.adj = make([][]link, len(s.Nodes))
+
+	type key struct {
+		source, target string
+		relType        domain.RelationType
+	}
+	seen := make(map[key]bool)
+	for _, rel := range rels {
+		for _, targetID := range rel.TargetIDs {
+			from, ok1 := s.index[rel.SourceID]
+			to, ok2 := s.index[targetID]
+			if !ok1 || !ok2 {
+				continue
+			}
+			def, known := registry.RelationType(rel.Type)
+			if known && def.Inverse != "" && seen[key{targetID, rel.SourceID, def.Inverse}] {
+				continue
+			}
+			seen[key{rel.SourceID, targetID, rel.Type}] = true
+
+			kept := *rel
+			kept.TargetIDs = []string{targetID}
+			s.Relationships = append(s.Relationships, &kept)
+			weight := rel.EffectiveWeight()
+			s.out[from] = append(s.out[from], link{to, weight})
+			s.in[to] = append(s.in[to], link{from, weight})
+			if known && !def.Directed {
+				s.out[to] = append(s.out[to], link{from, weight})
+				s.in[from] = append(s.in[from], link{to, weight})
+			}
+			if from != to {
+				s.adj[from] = append(s.adj[from], link{to, weight})
+				s.adj[to] = append(s.adj[to], link{from, weight})
+			}
+		}
+	}
+	return s
+}
+
+// Node returns the node with the given id, or nil if the snapshot does not hold it.
+func (s *Snapshot) Node(id string) *domain.Node {
+	if i, ok := s.index[id]; ok {
+		return s.Nodes[i]
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// Weights of the signals combined into the score of a link suggestion. They add up to 1.
+const (
+	similarityWeight = 0.5
+	sharedTagsWeight = 0.3
+	neighboursWeight = 0.2
+)
+
+// stopWords are left out of the text similarity.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"are": true, "was": true, "from": true, "into": true, "its": true, "not": true,
+	"but": true, "can": true, "has": true, "have": true, "how": true, "what": true,
+}
+
+// Suggestion is a node worth linking to, with the signals behind its score.
+type Suggestion struct {
+	Node *domain.Node
+	// Score combines the signals and lies between 0 and 1.
+	Score float64
+	// Similarity is the TF-IDF cosine similarity of title and content.
+	Similarity float64
+	// SharedTags are the tags both nodes carry.
+	SharedTags []string
+	// CommonNeighbours counts the nodes linked to both nodes.
+	CommonNeighbours int
+}
+
+// SuggestLinks returns up to limit nodes that are not linked to nodeID yet, ordered by
+// descending score. The score combines the similarity of title and content, the share of
+// common tags and the share of common neighbours. Nodes without any signal are left out.
+func (s *Snapshot) SuggestLinks(nodeID string, limit int) []Suggestion {
+	from, ok := s.index[nodeID]
+	if !ok {
+		return nil
+	}
+	vectors := s.tfidf(func(n *domain.Node) string { return n.Title + " " + n.Content })
+	neighbours := make([]map[int]bool, len(s.Nodes))
+	for i := range s.Nodes {
+		neighbours[i] = make(map[int]bool)
+		for _, l := range s.adj[i] {
+			neighbours[i][l.node] = true
+		}
+	}
+
+	var suggestions []Suggestion
+	for i, node := range s.Nodes {
+		if i == from || neighbours[from][i] {
+			continue
+		}
+		sg := Suggestion{
+			Node:       node,
+			Similarity: cosine(vectors[from], vectors[i]),
+			SharedTags: sharedTags(s.Nodes[from].Tags, node.Tags),
+		}
+		for n := range neighbours[from] {
+			if neighbours[i][n] {
+				sg.CommonNeighbours++
+			}
+		}
+		sg.Score = similarityWeight*sg.Similarity +
+			sharedTagsWeight*jaccard(len(sg.SharedTags), len(s.Nodes[from].Tags), len(node.Tags)) +
+			neighboursWeight*jaccard(sg.CommonNeighbours, len(neighbours[from]), len(neighbours[i]))
+		if sg.Score > 0 {
+			suggestions = append(suggestions, sg)
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Score > suggestions[j].Score })
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// tfidf returns the normalised TF-IDF vector of the text of every node.
+func (s *Snapshot) tfidf(text func(*domain.Node) string) []map[string]float64 {
+	counts := make([]map[string]int, len(s.Nodes))
+	df := make(map[string]int)
+	for i, n := range s.Nodes {
+		counts[i] = make(map[string]int)
+		for _, term := range terms(text(n)) {
+			if counts[i][term] == 0 {
+				df[term]++
+			}
+			counts[i][term]++
+		}
+	}
+	vectors := make([]map[string]float64, len(s.Nodes))
+	for i, c := range counts {
+		vectors[i] = make(map[string]float64, len(c))
+		var norm float64
+		for term, count := range c {
+			idf := math.Log(float64(len(s.Nodes)+1)/float64(df[term]+1)) + 1
+			w := float64(count) * idf
+			vectors[i][term] = w
+			norm += w * w
+		}
+		for term := range vectors[i] {
+			vectors[i][term] /= math.Sqrt(norm)
+		}
+	}
+	return vectors
+}
+
+// terms splits text into lower-case words of at least three letters or digits, without
+// stop words.
+func terms(text string) []string {
+	var result []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) >= 3 && !stopWords[word] {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+// cosine returns the cosine similarity of two normalised vectors.
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
+
+func sharedTags(a, b []string) []string {
+	var shared []string
+	for _, tag := range a {
+		for _, other := range b {
+			if tag == other {
+				shared = append(shared, tag)
+				break
+			}
+		}
+	}
+	return shared
+}
+
+// jaccard returns the share of common elements in the union of two sets.
+func jaccard(common, a, b int) float64 {
+	if union := a + b - common; union > 0 {
+		return float64(common) / float64(union)
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package analytics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func TestSuggestLinks(t *testing.T) {
+	nodes := []*domain.Node{
+		{ID: "chan", Title: "Channels", Content: "Goroutines communicate over channels.", Tags: []string{"go"}},
+		{ID: "select", Title: "Select", Content: "Select waits on several channels at once.", Tags: []string{"go"}},
+		{ID: "gor", Title: "Goroutines", Content: "Lightweight threads started with the go keyword."},
+		{ID: "mutex", Title: "Mutex", Content: "Locks protect shared memory.", Tags: []string{"go", "sync"}},
+		{ID: "bread", Title: "Bread", Content: "Flour, water and salt."},
+		{ID: "hub", Title: "Concurrency"},
+	}
+	rels := []*domain.Relationship{
+		{ID: "1", SourceID: "chan", TargetIDs: []string{"gor"}, Type: domain.RelatedTo},
+		{ID: "2", SourceID: "chan", TargetIDs: []string{"hub"}, Type: domain.RelatedTo},
+		{ID: "3", SourceID: "mutex", TargetIDs: []string{"hub"}, Type: domain.RelatedTo},
+	}
+	snapshot := analytics.NewSnapshot(nodes, rels, domain.NewTypeRegistry(nil, nil))
+
+	suggestions := snapshot.SuggestLinks("chan", 10)
+	var ids []string
+	for _, s := range suggestions {
+		ids = append(ids, s.Node.ID)
+	}
+	assert.Equal(t, []string{"select", "mutex"}, ids, "Linked, unrelated and the node itself should not be suggested")
+	assert.Greater(t, suggestions[0].Similarity, 0.0, "Shared words should count")
+	assert.Equal(t, []string{"go"}, suggestions[0].SharedTags)
+	assert.Equal(t, 1, suggestions[1].CommonNeighbours, "The shared neighbour should count")
+	assert.Zero(t, suggestions[1].Similarity)
+
+	assert.Len(t, snapshot.SuggestLinks("chan", 1), 1, "The limit should be applied")
+	assert.Empty(t, snapshot.SuggestLinks("unknown", 10))
+}
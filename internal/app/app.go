@@ -0,0 +1,41 @@
+// Package app wires the use cases of a workspace together for the command line and the UI.
+// It does not depend on the UI toolkit, so commands and their tests build without it.
+package app
+
+import (
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// Services bundles the use cases of one workspace.
+type Services struct {
+	Nodes *usecase.NodeUseCase
+	Tags  *usecase.TagUseCase
+	Types *usecase.TypeUseCase
+	Views *usecase.ViewUseCase
+}
+
+// Workspaces describes the workspaces offered by the workspace switcher.
+type Workspaces struct {
+	// Names lists the configured workspaces. Workspaces found in storage are added to it.
+	Names []string
+	// Switch opens the named workspace and returns the services scoped to it.
+	Switch func(name string) (Services, error)
+}
+
+// Storage is implemented by both the Neo4j and the in-memory repository.
+type Storage interface {
+	usecase.NodeRepository
+	usecase.TagRepository
+	usecase.TypeRepository
+	usecase.ViewRepository
+}
+
+// NewServices creates the use cases of one workspace.
+func NewServices(repo Storage) Services {
+	return Services{
+		Nodes: usecase.NewNodeUseCase(repo, repo),
+		Tags:  usecase.NewTagUseCase(repo),
+		Types: usecase.NewTypeUseCase(repo),
+		Views: usecase.NewViewUseCase(repo),
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// RunCommand runs the command given after the flags instead of starting the UI.
+func RunCommand(ctx context.Context, services Services, args []string, out io.Writer) error {
+	switch args[0] {
+	case "reading-list":
+		if len(args) != 2 {
+			return fmt.Errorf("usage: reading-list <node title or id>")
+		}
+		node, err := findNode(ctx, services.Nodes, args[1])
+		if err != nil {
+			return err
+		}
+		list, err := services.Nodes.ReadingList(ctx, node.ID)
+		if err != nil {
+			return err
+		}
+		for i, n := range list {
+			fmt.Fprintf(out, "%d. %s (%s)\n", i+1, n.Title, n.Type)
+		}
+		return nil
+	case "analytics":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: analytics")
+		}
+		report, err := services.Nodes.Analyze(ctx)
+		if err != nil {
+			return err
+		}
+		printReport(out, report)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// rankingSize is the number of nodes listed under "Most central" by the analytics command.
+const rankingSize = 10
+
+// printReport writes the sections of an analytics report.
+func printReport(out io.Writer, report *analytics.Report) {
+	fmt.Fprintln(out, "Most central:")
+	for i, n := range report.Ranking {
+		if i == rankingSize {
+			break
+		}
+		d := report.Degrees[n.ID]
+		fmt.Fprintf(out, "%d. %s (PageRank %.3f, %d links: %d in, %d out)\n", i+1, n.Title, report.PageRank[n.ID], d.Total, d.In, d.Out)
+	}
+	printGroups(out, "Components", report.Components)
+	printGroups(out, "Communities", report.Communities)
+	fmt.Fprintf(out, "Orphans (%d): %s\n", len(report.Orphans), titles(report.Orphans))
+	fmt.Fprintf(out, "Dead ends (%d): %s\n", len(report.DeadEnds), titles(report.DeadEnds))
+}
+
+func printGroups(out io.Writer, name string, groups [][]*domain.Node) {
+	fmt.Fprintf(out, "%s (%d):\n", name, len(groups))
+	for i, group := range groups {
+		fmt.Fprintf(out, "%d. %s\n", i+1, titles(group))
+	}
+}
+
+// titles joins the node titles with commas.
+func titles(nodes []*domain.Node) string {
+	list := make([]string, len(nodes))
+	for i, n := range nodes {
+		list[i] = n.Title
+	}
+	return strings.Join(list, ", ")
+}
+
+// findNode looks a node up by id, then by exact title ignoring case.
+func findNode(ctx context.Context, nodes *usecase.NodeUseCase, ref string) (*domain.Node, error) {
+	if node, err := nodes.GetNode(ctx, ref); err == nil {
+		return node, nil
+	}
+	matches, err := nodes.SearchNodes(ctx, ref, "Title/Content")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range matches {
+		if strings.EqualFold(n.Title, ref) {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("no node with id or title %q", ref)
+}
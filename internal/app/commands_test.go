@@ -0,0 +1,54 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+)
+
+func TestReadingListCommand(t *testing.T) {
+	ctx := context.Background()
+	services := NewServices(memory.NewNodeRepository())
+
+	basicsID, err := services.Nodes.CreateNode(ctx, &domain.Node{Title: "Basics", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	closuresID, err := services.Nodes.CreateNode(ctx, &domain.Node{Title: "Closures", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = services.Nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: closuresID, TargetIDs: []string{basicsID}, Type: domain.DependsOn})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	var out bytes.Buffer
+	err = RunCommand(ctx, services, []string{"reading-list", "closures"}, &out)
+	assert.NoError(t, err, "reading-list should succeed")
+	assert.Equal(t, "1. Basics (CONCEPT)\n2. Closures (NOTE)\n", out.String())
+
+	assert.Error(t, RunCommand(ctx, services, []string{"reading-list", "Generics"}, &out), "Unknown nodes should be reported")
+	assert.Error(t, RunCommand(ctx, services, []string{"export"}, &out), "Unknown commands should be reported")
+}
+
+func TestAnalyticsCommand(t *testing.T) {
+	ctx := context.Background()
+	services := NewServices(memory.NewNodeRepository())
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Basics", "Closures", "Drafts"} {
+		id, err := services.Nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	_, err := services.Nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Closures"], TargetIDs: []string{ids["Basics"]}, Type: domain.DependsOn})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	var out bytes.Buffer
+	err = RunCommand(ctx, services, []string{"analytics"}, &out)
+	assert.NoError(t, err, "analytics should succeed")
+	assert.Contains(t, out.String(), "Most central:\n1. Basics (PageRank ")
+	assert.Contains(t, out.String(), "Components (2):\n1. Basics, Closures\n2. Drafts\n")
+	assert.Contains(t, out.String(), "Orphans (1): Drafts\n")
+	assert.Contains(t, out.String(), "Dead ends (1): Basics\n")
+}
@@ -0,0 +1,169 @@
+// Package config loads the application settings from an optional JSON file
+// and command line flags. Flags take precedence over the file.
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+const (
+	BackendNeo4j  = "neo4j"
+	BackendMemory = "memory"
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type Config struct {
+	// Backend selects the storage: BackendNeo4j or BackendMemory.
+	Backend string `json:"backend"`
+	Neo4j   Neo4j  `json:"neo4j"`
+	// Workspace is the name of the workspace opened at startup.
+	Workspace string `json:"workspace"`
+	// Workspaces lists the known workspaces. A workspace that is not listed
+	// is stored in the default database.
+	Workspaces []domain.Workspace `json:"workspaces"`
+	// Theme styles the graph by node and relationship type. Styles in the file are added
+	// to the default theme, replacing the style of the same type.
+	Theme domain.GraphTheme `json:"theme"`
+	// Args holds the command line arguments left after the flags.
+	Args []string `json:"-"`
+}
+
+type Neo4j struct {
+	URI      string `json:"uri"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Default returns the settings used when neither a file nor flags override them.
+func Default() *Config {
+	return &Config{
+		Backend: BackendNeo4j,
+		Neo4j: Neo4j{
+			URI:      "bolt://localhost:7687",
+			Username: "neo4j",
+			Password: "password",
+		},
+		Workspace: domain.DefaultWorkspace,
+		Theme:     domain.DefaultGraphTheme(),
+	}
+}
+
+// Load parses args (without the program name) and merges them over the file given by -config.
+func Load(args []string) (*Config, error) {
+	fs := flag.NewFlagSet("knowledge-manager", flag.ContinueOnError)
+	path := fs.String("config", "", "path to a JSON config file")
+	backend := fs.String("backend", "", "storage backend: neo4j or memory")
+	uri := fs.String("neo4j-uri", "", "Neo4j bolt URI")
+	username := fs.String("neo4j-user", "", "Neo4j username")
+	password := fs.String("neo4j-password", "", "Neo4j password")
+	workspace := fs.String("workspace", "", "workspace opened at startup")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		data, err := os.ReadFile(*path)
+		if err != nil {
+			return nil, fmt.Errorf("read config: %w", err)
+		}
+		if err = json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parse config %s: %w", *path, err)
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "backend":
+			cfg.Backend = *backend
+		case "neo4j-uri":
+			cfg.Neo4j.URI = *uri
+		case "neo4j-user":
+			cfg.Neo4j.Username = *username
+		case "neo4j-password":
+			cfg.Neo4j.Password = *password
+		case "workspace":
+			cfg.Workspace = *workspace
+		}
+	})
+	cfg.Args = fs.Args()
+
+	if cfg.Backend != BackendNeo4j && cfg.Backend != BackendMemory {
+		return nil, fmt.Errorf("unknown backend %q", cfg.Backend)
+	}
+	if cfg.Workspace == "" {
+		cfg.Workspace = domain.DefaultWorkspace
+	}
+	if err := validateTheme(cfg.Theme); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validateTheme checks the colours, shapes and line styles of the theme. Empty values
+// fall back to the defaults.
+func validateTheme(theme domain.GraphTheme) error {
+	for name, style := range theme.Nodes {
+		if style.Color != "" && !colorPattern.MatchString(style.Color) {
+			return fmt.Errorf("theme: node type %s: colour must be a #RRGGBB hex value", name)
+		}
+		if style.Shape != "" && !contains(domain.Shapes, style.Shape) {
+			return fmt.Errorf("theme: node type %s: unknown shape %q", name, style.Shape)
+		}
+	}
+	for name, style := range theme.Relationships {
+		if style.Color != "" && !colorPattern.MatchString(style.Color) {
+			return fmt.Errorf("theme: relationship type %s: colour must be a #RRGGBB hex value", name)
+		}
+		if style.Line != "" && !contains(domain.LineStyles, style.Line) {
+			return fmt.Errorf("theme: relationship type %s: unknown line style %q", name, style.Line)
+		}
+		if style.Width < 0 {
+			return fmt.Errorf("theme: relationship type %s: width must not be negative", name)
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkspaceByName returns the configured workspace with the given name,
+// or a workspace in the default database if it is not configured.
+func (c *Config) WorkspaceByName(name string) domain.Workspace {
+	for _, ws := range c.Workspaces {
+		if ws.Name == name {
+			return ws
+		}
+	}
+	return domain.Workspace{Name: name}
+}
+
+// ActiveWorkspace returns the workspace opened at startup.
+func (c *Config) ActiveWorkspace() domain.Workspace {
+	return c.WorkspaceByName(c.Workspace)
+}
+
+// WorkspaceNames returns the names of the configured workspaces, starting with the active one.
+func (c *Config) WorkspaceNames() []string {
+	names := []string{c.Workspace}
+	for _, ws := range c.Workspaces {
+		if ws.Name != c.Workspace {
+			names = append(names, ws.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(nil)
+	assert.NoError(t, err, "Load without arguments should succeed")
+	assert.Equal(t, BackendNeo4j, cfg.Backend, "Default backend should be neo4j")
+	assert.Equal(t, domain.DefaultWorkspace, cfg.Workspace, "Default workspace should be selected")
+}
+
+func TestLoadFlagsOverrideFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	file := `{
+		"backend": "memory",
+		"workspace": "research",
+		"workspaces": [{"name": "research", "database": "research"}, {"name": "ops"}]
+	}`
+	err := os.WriteFile(path, []byte(file), 0o600)
+	assert.NoError(t, err, "Writing the config file should succeed")
+
+	cfg, err := Load([]string{"-config", path, "-workspace", "ops", "report"})
+	assert.NoError(t, err, "Load should succeed")
+	assert.Equal(t, BackendMemory, cfg.Backend, "Backend should come from the file")
+	assert.Equal(t, "ops", cfg.Workspace, "Workspace flag should override the file")
+	assert.Equal(t, []string{"report"}, cfg.Args, "Remaining arguments should be kept")
+	assert.Equal(t, domain.Workspace{Name: "research", Database: "research"}, cfg.WorkspaceByName("research"),
+		"Configured workspace should keep its database")
+	assert.Equal(t, []string{"ops", "research"}, cfg.WorkspaceNames(), "Active workspace should be listed first")
+}
+
+func TestLoadRejectsUnknownBackend(t *testing.T) {
+	_, err := Load([]string{"-backend", "sqlite"})
+	assert.Error(t, err, "Unknown backend should be rejected")
+}
+
+func TestLoadTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	file := `{
+		"theme": {
+			"nodes": {"PERSON": {"color": "#FF0000", "shape": "rounded"}},
+			"relationships": {"DEPENDS_ON": {"line": "dotted", "width": 3}},
+			"size_by_degree": true
+		}
+	}`
+	err := os.WriteFile(path, []byte(file), 0o600)
+	assert.NoError(t, err, "Writing the config file should succeed")
+
+	cfg, err := Load([]string{"-config", path})
+	assert.NoError(t, err, "Load should succeed")
+	assert.True(t, cfg.Theme.SizeByDegree)
+	assert.Equal(t, domain.NodeStyle{Color: "#FF0000", Shape: domain.ShapeRounded},
+		cfg.Theme.NodeStyle(domain.NodeTypeDef{Name: "PERSON", Color: "#00FF00"}), "The theme colour should win")
+	assert.Equal(t, domain.NodeStyle{Color: "#43A047", Shape: domain.ShapeCircle},
+		cfg.Theme.NodeStyle(domain.NodeTypeDef{Name: domain.Note, Color: "#43A047"}), "Unstyled types should keep their colour")
+	assert.Equal(t, domain.EdgeStyle{Color: domain.DefaultEdgeColor, Line: domain.LineDotted, Width: 3},
+		cfg.Theme.EdgeStyle(domain.DependsOn), "A configured style should replace the default one")
+	assert.Equal(t, domain.LineDashed, cfg.Theme.EdgeStyle(domain.References).Line, "Other defaults should be kept")
+
+	err = os.WriteFile(path, []byte(`{"theme": {"nodes": {"NOTE": {"shape": "star"}}}}`), 0o600)
+	assert.NoError(t, err, "Writing the config file should succeed")
+	_, err = Load([]string{"-config", path})
+	assert.Error(t, err, "Unknown shapes should be rejected")
+}
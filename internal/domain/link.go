@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wikiLink matches a link to another node written as [[Title]] in the content of a node.
+var wikiLink = regexp.MustCompile(`\[\[([^\[\]]+)\]\]`)
+
+// WikiLinks returns the titles linked as [[Title]] in content in order of appearance,
+// trimmed and without repeats.
+func WikiLinks(content string) []string {
+	var titles []string
+	seen := make(map[string]bool)
+	for _, m := range wikiLink.FindAllStringSubmatch(content, -1) {
+		title := strings.TrimSpace(m[1])
+		if title == "" || seen[strings.ToLower(title)] {
+			continue
+		}
+		seen[strings.ToLower(title)] = true
+		titles = append(titles, title)
+	}
+	return titles
+}
+
+// ReplaceWikiLinks replaces every [[Title]] link in content with the result of replace
+// for the trimmed title.
+func ReplaceWikiLinks(content string, replace func(title string) string) string {
+	return wikiLink.ReplaceAllStringFunc(content, func(link string) string {
+		return replace(strings.TrimSpace(link[2 : len(link)-2]))
+	})
+}
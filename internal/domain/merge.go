@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// MergeOptions choose the title and content of the node that survives a merge.
+type MergeOptions struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// MergeRecord describes a merge of two nodes, with everything needed to undo it.
+type MergeRecord struct {
+	// Survivor is the kept node as it was before the merge.
+	Survivor *Node `json:"survivor"`
+	// Merged is the deleted node.
+	Merged *Node `json:"merged"`
+	// Relationships are the relationships of Merged as they were before the merge.
+	Relationships []*Relationship `json:"relationships"`
+	// Dropped lists the relationships that were deleted instead of moved to Survivor,
+	// because they linked the two nodes or repeated a link of Survivor.
+	Dropped []string `json:"dropped,omitempty"`
+	// Revisions are the former versions of Merged, newest first.
+	Revisions []NodeRevision `json:"revisions,omitempty"`
+	MergedAt  time.Time      `json:"merged_at"`
+}
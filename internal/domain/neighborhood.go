@@ -0,0 +1,30 @@
+package domain
+
+// Direction selects which relationships of a node are followed.
+type Direction string
+
+const (
+	DirectionBoth     Direction = ""
+	DirectionOutgoing Direction = "OUTGOING"
+	DirectionIncoming Direction = "INCOMING"
+)
+
+// MaxNeighborhoodDepth is the largest number of hops a neighbourhood may span.
+const MaxNeighborhoodDepth = 10
+
+// Directions lists the valid directions.
+var Directions = []Direction{DirectionBoth, DirectionOutgoing, DirectionIncoming}
+
+// NeighborhoodFilter restricts the relationships followed when expanding a neighbourhood.
+type NeighborhoodFilter struct {
+	// RelationTypes limits the relationships followed, empty follows every type.
+	RelationTypes []RelationType `json:"relation_types,omitempty"`
+	Direction     Direction      `json:"direction,omitempty"`
+}
+
+// Neighborhood is a node together with the nodes reachable from it and the relationships
+// among them. Nodes are ordered by distance from the start node, which comes first.
+type Neighborhood struct {
+	Nodes         []*Node         `json:"nodes"`
+	Relationships []*Relationship `json:"relationships"`
+}
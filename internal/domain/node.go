@@ -20,4 +20,7 @@ type Node struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Tags      []string  `json:"tags"`
+	// Properties holds the custom properties declared by the node type, in the
+	// canonical text form of their kind.
+	Properties map[string]string `json:"properties,omitempty"`
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// DefaultMaxPathDepth is the number of hops path searches look at when no limit is given.
+const DefaultMaxPathDepth = 6
+
+// Path is a walk between nodes. RelationshipIDs[i] links NodeIDs[i] and NodeIDs[i+1].
+type Path struct {
+	NodeIDs         []string `json:"node_ids"`
+	RelationshipIDs []string `json:"relationship_ids"`
+	// Cost is the total weight of the relationships, set by weighted searches.
+	Cost float64 `json:"cost,omitempty"`
+}
+
+// PathOptions restrict a path search. By default the paths with the fewest hops are
+// searched and relationships are followed in both directions.
+type PathOptions struct {
+	// RelationTypes limits the relationships followed, empty follows every type.
+	RelationTypes []RelationType `json:"relation_types,omitempty"`
+	// MaxDepth is the longest path in hops, 0 means DefaultMaxPathDepth.
+	MaxDepth int `json:"max_depth,omitempty"`
+	// All returns every shortest path instead of a single one.
+	All bool `json:"all,omitempty"`
+	// Weighted searches the single path of lowest total weight instead. Relationships of
+	// directed types are then only followed from source to target, and MaxDepth and All
+	// do not apply.
+	Weighted bool `json:"weighted,omitempty"`
+	// At follows only the relationships valid on that day, nil follows all of them.
+	At *time.Time `json:"at,omitempty"`
+}
+
+// Depth returns MaxDepth, or DefaultMaxPathDepth if no limit is set.
+func (o PathOptions) Depth() int {
+	if o.MaxDepth <= 0 {
+		return DefaultMaxPathDepth
+	}
+	return o.MaxDepth
+}
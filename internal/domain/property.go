@@ -0,0 +1,38 @@
+package domain
+
+// PropertyKind is the value type of a custom node property.
+type PropertyKind string
+
+const (
+	PropertyString PropertyKind = "string"
+	PropertyNumber PropertyKind = "number"
+	PropertyBool   PropertyKind = "bool"
+	PropertyDate   PropertyKind = "date"
+	PropertyURL    PropertyKind = "url"
+	PropertyEnum   PropertyKind = "enum"
+)
+
+// PropertyKinds lists the supported property kinds.
+var PropertyKinds = []PropertyKind{PropertyString, PropertyNumber, PropertyBool, PropertyDate, PropertyURL, PropertyEnum}
+
+// PropertyDateLayout is the canonical format of date property values.
+const PropertyDateLayout = "2006-01-02"
+
+// PropertyDef declares a custom property of a node type.
+// Options lists the allowed values of enum properties.
+type PropertyDef struct {
+	Name     string       `json:"name"`
+	Kind     PropertyKind `json:"kind"`
+	Required bool         `json:"required,omitempty"`
+	Options  []string     `json:"options,omitempty"`
+}
+
+// Property returns the definition of the named property of the node type.
+func (d NodeTypeDef) Property(name string) (PropertyDef, bool) {
+	for _, p := range d.Properties {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return PropertyDef{}, false
+}
@@ -13,6 +13,9 @@ const (
 	IsPrecededBy RelationType = "IS_PRECEDED_BY"
 )
 
+// RelationTypes lists the built-in relationship types.
+var RelationTypes = []RelationType{RelatedTo, References, IsPartOf, HasPart, DependsOn, IsPrecededBy}
+
 type Relationship struct {
 	ID          string       `json:"id"`
 	SourceID    string       `json:"source_id"`
@@ -20,4 +23,41 @@ type Relationship struct {
 	Type        RelationType `json:"type"`
 	Description string       `json:"description"`
 	CreatedAt   time.Time    `json:"created_at"`
+	// Weight is the cost of following the link in weighted path searches, 0 means the default of 1.
+	Weight float64 `json:"weight,omitempty"`
+	// Confidence in the link between 0 and 1, 0 means unknown.
+	Confidence float64 `json:"confidence,omitempty"`
+	// ValidFrom and ValidTo bound the dates on which the link holds, both inclusive.
+	// A nil bound is open.
+	ValidFrom *time.Time `json:"valid_from,omitempty"`
+	ValidTo   *time.Time `json:"valid_to,omitempty"`
+}
+
+// DefaultWeight is the weight of relationships without an explicit weight.
+const DefaultWeight = 1.0
+
+// EffectiveWeight returns the weight, or DefaultWeight if none is set.
+func (r *Relationship) EffectiveWeight() float64 {
+	if r.Weight == 0 {
+		return DefaultWeight
+	}
+	return r.Weight
+}
+
+// ValidAt reports whether the relationship holds on the day of at.
+func (r *Relationship) ValidAt(at time.Time) bool {
+	day := DateOf(at)
+	if r.ValidFrom != nil && day.Before(DateOf(*r.ValidFrom)) {
+		return false
+	}
+	if r.ValidTo != nil && day.After(DateOf(*r.ValidTo)) {
+		return false
+	}
+	return true
+}
+
+// DateOf truncates t to midnight UTC of its calendar day.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// MaxNodeRevisions is the number of former versions kept per node. Saving a node that has
+// as many drops the oldest.
+const MaxNodeRevisions = 50
+
+// NodeRevision is a former version of a node, kept each time the node is updated.
+type NodeRevision struct {
+	NodeID     string            `json:"node_id"`
+	Title      string            `json:"title"`
+	Content    string            `json:"content"`
+	Type       NodeType          `json:"type"`
+	Tags       []string          `json:"tags"`
+	Properties map[string]string `json:"properties,omitempty"`
+	// SavedAt is the time the version was saved, the update time of the node back then.
+	SavedAt time.Time `json:"saved_at"`
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "strings"
+
+// TagSeparator separates the levels of a hierarchical tag such as "lang/go/concurrency".
+const TagSeparator = "/"
+
+// Tag is a label attached to nodes, with the number of nodes using it directly.
+// Parent is the enclosing tag of a hierarchical tag, empty for top-level tags.
+type Tag struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+	Count  int    `json:"count"`
+}
+
+// NormalizeTag trims every level of a hierarchical tag and drops empty levels,
+// so " lang / go/ " becomes "lang/go".
+func NormalizeTag(name string) string {
+	var levels []string
+	for _, level := range strings.Split(name, TagSeparator) {
+		if level = strings.TrimSpace(level); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, TagSeparator)
+}
+
+// NormalizeTags normalizes every tag and drops empty and duplicate ones, keeping the order.
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var result []string
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// ParentTag returns the parent of a hierarchical tag, or "" for a top-level tag.
+func ParentTag(name string) string {
+	i := strings.LastIndex(name, TagSeparator)
+	if i < 0 {
+		return ""
+	}
+	return name[:i]
+}
+
+// TagAncestors returns the ancestors of a tag from the nearest parent to the root.
+func TagAncestors(name string) []string {
+	var ancestors []string
+	for parent := ParentTag(name); parent != ""; parent = ParentTag(parent) {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// IsTagWithin reports whether name equals ancestor or is one of its descendants.
+func IsTagWithin(name, ancestor string) bool {
+	return name == ancestor || strings.HasPrefix(name, ancestor+TagSeparator)
+}
+
+// RebaseTag moves a tag within oldRoot under newRoot, so "lang/go" rebased from
+// "lang" to "languages" becomes "languages/go". Other tags are returned unchanged.
+func RebaseTag(name, oldRoot, newRoot string) string {
+	if !IsTagWithin(name, oldRoot) {
+		return name
+	}
+	return newRoot + name[len(oldRoot):]
+}
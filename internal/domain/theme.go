@@ -0,0 +1,98 @@
+package domain
+
+// Node shapes of a NodeStyle.
+const (
+	ShapeCircle  = "circle"
+	ShapeSquare  = "square"
+	ShapeRounded = "rounded"
+	ShapeRing    = "ring"
+)
+
+// Shapes lists the node shapes, the default first.
+var Shapes = []string{ShapeCircle, ShapeSquare, ShapeRounded, ShapeRing}
+
+// Line styles of an EdgeStyle.
+const (
+	LineSolid  = "solid"
+	LineDashed = "dashed"
+	LineDotted = "dotted"
+)
+
+// LineStyles lists the line styles, the default first.
+var LineStyles = []string{LineSolid, LineDashed, LineDotted}
+
+// DefaultEdgeColor and DefaultEdgeWidth draw relationships of types without a style.
+const (
+	DefaultEdgeColor = "#424242"
+	DefaultEdgeWidth = 2.0
+)
+
+// NodeStyle is how the nodes of a type are drawn. An empty Color keeps the colour of the
+// type definition.
+type NodeStyle struct {
+	Color string `json:"color,omitempty"`
+	Shape string `json:"shape,omitempty"`
+}
+
+// EdgeStyle is how the relationships of a type are drawn. Color is a "#RRGGBB" hex colour.
+type EdgeStyle struct {
+	Color string  `json:"color,omitempty"`
+	Line  string  `json:"line,omitempty"`
+	Width float64 `json:"width,omitempty"`
+}
+
+// GraphTheme maps node and relationship types to the way they are drawn.
+type GraphTheme struct {
+	Nodes         map[NodeType]NodeStyle     `json:"nodes,omitempty"`
+	Relationships map[RelationType]EdgeStyle `json:"relationships,omitempty"`
+	// HideEdgeLabels starts the graph without the type labels on the edges.
+	HideEdgeLabels bool `json:"hide_edge_labels,omitempty"`
+	// SizeByDegree draws nodes with more links larger.
+	SizeByDegree bool `json:"size_by_degree,omitempty"`
+}
+
+// DefaultGraphTheme styles the built-in relationship types. Nodes keep the colours of
+// their type definitions.
+func DefaultGraphTheme() GraphTheme {
+	return GraphTheme{
+		Nodes: map[NodeType]NodeStyle{
+			Reference: {Shape: ShapeSquare},
+		},
+		Relationships: map[RelationType]EdgeStyle{
+			RelatedTo:    {Color: "#9E9E9E", Line: LineDotted},
+			References:   {Color: "#FB8C00", Line: LineDashed},
+			IsPartOf:     {Color: "#1E88E5"},
+			HasPart:      {Color: "#1E88E5"},
+			DependsOn:    {Color: "#E53935"},
+			IsPrecededBy: {Color: "#8E24AA", Line: LineDashed},
+		},
+	}
+}
+
+// NodeStyle returns the style of nodes of the type, filled in from the type definition
+// and the defaults.
+func (t GraphTheme) NodeStyle(def NodeTypeDef) NodeStyle {
+	style := t.Nodes[def.Name]
+	if style.Color == "" {
+		style.Color = def.Color
+	}
+	if style.Shape == "" {
+		style.Shape = ShapeCircle
+	}
+	return style
+}
+
+// EdgeStyle returns the style of relationships of the type, filled in from the defaults.
+func (t GraphTheme) EdgeStyle(name RelationType) EdgeStyle {
+	style := t.Relationships[name]
+	if style.Color == "" {
+		style.Color = DefaultEdgeColor
+	}
+	if style.Line == "" {
+		style.Line = LineSolid
+	}
+	if style.Width <= 0 {
+		style.Width = DefaultEdgeWidth
+	}
+	return style
+}
@@ -0,0 +1,175 @@
+package domain
+
+import "sort"
+
+// NodeTypeDef describes a node type of the type registry.
+// Color is a "#RRGGBB" hex colour and Icon the name of a Fyne theme icon.
+// Properties is the schema of the custom properties of nodes of the type.
+type NodeTypeDef struct {
+	Name        NodeType      `json:"name"`
+	Color       string        `json:"color"`
+	Icon        string        `json:"icon,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Properties  []PropertyDef `json:"properties,omitempty"`
+}
+
+// RelationTypeDef describes a relationship type of the type registry.
+// Inverse names the type that reads the same link from the target, e.g. HAS_PART for IS_PART_OF.
+// Undirected types such as RELATED_TO read the same both ways and have no inverse.
+type RelationTypeDef struct {
+	Name        RelationType  `json:"name"`
+	Inverse     RelationType  `json:"inverse,omitempty"`
+	Directed    bool          `json:"directed"`
+	Description string        `json:"description,omitempty"`
+	Rules       RelationRules `json:"rules,omitempty"`
+}
+
+// RelationRules constrain the relationships of a type. The zero value allows everything.
+type RelationRules struct {
+	// Acyclic forbids links that close a cycle of relationships of the type.
+	Acyclic bool `json:"acyclic,omitempty"`
+	// NoSelfLoops forbids links from a node to itself.
+	NoSelfLoops bool `json:"no_self_loops,omitempty"`
+	// MaxOutDegree limits the links of the type starting at one node, 0 means no limit.
+	MaxOutDegree int `json:"max_out_degree,omitempty"`
+	// SourceTypes and TargetTypes restrict the node types at either end, empty allows any.
+	SourceTypes []NodeType `json:"source_types,omitempty"`
+	TargetTypes []NodeType `json:"target_types,omitempty"`
+}
+
+// IsZero reports whether the rules allow everything.
+func (r RelationRules) IsZero() bool {
+	return !r.Acyclic && !r.NoSelfLoops && r.MaxOutDegree == 0 && len(r.SourceTypes) == 0 && len(r.TargetTypes) == 0
+}
+
+// NodeTypes lists the built-in node types.
+var NodeTypes = []NodeType{Concept, Note, Reference}
+
+// BuiltinNodeTypes are the definitions of the built-in node types.
+// They can be restyled but not deleted.
+var BuiltinNodeTypes = []NodeTypeDef{
+	{Name: Concept, Color: "#1E88E5", Icon: "info", Description: "An idea or term"},
+	{Name: Note, Color: "#43A047", Icon: "document", Description: "A free-form note"},
+	{Name: Reference, Color: "#FB8C00", Icon: "file", Description: "An external source", Properties: []PropertyDef{
+		{Name: "url", Kind: PropertyURL},
+		{Name: "author", Kind: PropertyString},
+	}},
+}
+
+// BuiltinRelationTypes are the definitions of the built-in relationship types.
+var BuiltinRelationTypes = []RelationTypeDef{
+	{Name: RelatedTo, Description: "Loosely related"},
+	{Name: References, Directed: true, Description: "Cites the target"},
+	{Name: IsPartOf, Inverse: HasPart, Directed: true, Description: "Is a component of the target"},
+	{Name: HasPart, Inverse: IsPartOf, Directed: true, Description: "Contains the target"},
+	{Name: DependsOn, Directed: true, Description: "Requires the target", Rules: RelationRules{Acyclic: true, NoSelfLoops: true}},
+	{Name: IsPrecededBy, Directed: true, Description: "Comes after the target", Rules: RelationRules{Acyclic: true, NoSelfLoops: true}},
+}
+
+// TypeRegistry holds the node and relationship types known in a workspace.
+type TypeRegistry struct {
+	NodeTypes     []NodeTypeDef     `json:"node_types"`
+	RelationTypes []RelationTypeDef `json:"relation_types"`
+}
+
+// NewTypeRegistry returns the built-in types followed by the given custom types sorted by name.
+// A custom definition with the name of a built-in type replaces the built-in definition.
+func NewTypeRegistry(nodeTypes []NodeTypeDef, relationTypes []RelationTypeDef) *TypeRegistry {
+	r := &TypeRegistry{}
+
+	customNodes := make(map[NodeType]NodeTypeDef, len(nodeTypes))
+	for _, def := range nodeTypes {
+		customNodes[def.Name] = def
+	}
+	for _, def := range BuiltinNodeTypes {
+		if custom, ok := customNodes[def.Name]; ok {
+			def = custom
+			delete(customNodes, def.Name)
+		}
+		r.NodeTypes = append(r.NodeTypes, def)
+	}
+	var extraNodes []NodeTypeDef
+	for _, def := range customNodes {
+		extraNodes = append(extraNodes, def)
+	}
+	sort.Slice(extraNodes, func(i, j int) bool { return extraNodes[i].Name < extraNodes[j].Name })
+	r.NodeTypes = append(r.NodeTypes, extraNodes...)
+
+	customRels := make(map[RelationType]RelationTypeDef, len(relationTypes))
+	for _, def := range relationTypes {
+		customRels[def.Name] = def
+	}
+	for _, def := range BuiltinRelationTypes {
+		if custom, ok := customRels[def.Name]; ok {
+			def = custom
+			delete(customRels, def.Name)
+		}
+		r.RelationTypes = append(r.RelationTypes, def)
+	}
+	var extraRels []RelationTypeDef
+	for _, def := range customRels {
+		extraRels = append(extraRels, def)
+	}
+	sort.Slice(extraRels, func(i, j int) bool { return extraRels[i].Name < extraRels[j].Name })
+	r.RelationTypes = append(r.RelationTypes, extraRels...)
+
+	return r
+}
+
+// NodeType returns the definition of the named node type.
+func (r *TypeRegistry) NodeType(name NodeType) (NodeTypeDef, bool) {
+	for _, def := range r.NodeTypes {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return NodeTypeDef{}, false
+}
+
+// RelationType returns the definition of the named relationship type.
+func (r *TypeRegistry) RelationType(name RelationType) (RelationTypeDef, bool) {
+	for _, def := range r.RelationTypes {
+		if def.Name == name {
+			return def, true
+		}
+	}
+	return RelationTypeDef{}, false
+}
+
+// NodeTypeNames returns the node type names in registry order, as used by dropdowns.
+func (r *TypeRegistry) NodeTypeNames() []string {
+	names := make([]string, 0, len(r.NodeTypes))
+	for _, def := range r.NodeTypes {
+		names = append(names, string(def.Name))
+	}
+	return names
+}
+
+// RelationTypeNames returns the relationship type names in registry order.
+func (r *TypeRegistry) RelationTypeNames() []string {
+	names := make([]string, 0, len(r.RelationTypes))
+	for _, def := range r.RelationTypes {
+		names = append(names, string(def.Name))
+	}
+	return names
+}
+
+// IsBuiltinNodeType reports whether name is one of the built-in node types.
+func IsBuiltinNodeType(name NodeType) bool {
+	for _, t := range NodeTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBuiltinRelationType reports whether name is one of the built-in relationship types.
+func IsBuiltinRelationType(name RelationType) bool {
+	for _, t := range RelationTypes {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
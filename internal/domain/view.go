@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// DefaultView is the view manual node positions are saved to while no saved view is open.
+const DefaultView = "default"
+
+// DefaultZoom is the zoom of a view that was saved without one.
+const DefaultZoom = 1.0
+
+// Position is the top-left corner of a node on the graph canvas.
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// View is a named arrangement of the graph of a workspace: the search that selects the
+// shown nodes, where the user moved them and how far the canvas is zoomed. An empty
+// Query shows every node.
+type View struct {
+	Name      string
+	Query     string
+	Criteria  string
+	Layout    string
+	Positions map[string]Position
+	Zoom      float64
+	UpdatedAt time.Time
+}
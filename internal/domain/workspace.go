@@ -0,0 +1,12 @@
+package domain
+
+// DefaultWorkspace is the workspace used when none is selected.
+const DefaultWorkspace = "default"
+
+// Workspace isolates the nodes of one team.
+// Nodes are always stamped with the workspace name; when Database is set the
+// workspace additionally lives in its own Neo4j database.
+type Workspace struct {
+	Name     string `json:"name"`
+	Database string `json:"database,omitempty"`
+}
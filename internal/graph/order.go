@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrCycle is wrapped by *CycleError.
+var ErrCycle = errors.New("cycle")
+
+// CycleError reports a cycle found while ordering. Path starts and ends at the same node.
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "cycle: " + strings.Join(e.Path, " -> ")
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
+// TopologicalOrder returns startID and every node reachable from it, ordered so that each
+// node comes after all nodes its edges lead to. With edges pointing at prerequisites this
+// is a reading order ending with startID. Ties keep the edge insertion order. A cycle
+// reachable from startID fails with a *CycleError.
+func (g *Graph) TopologicalOrder(startID string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var order, stack []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			start := len(stack) - 1
+			for stack[start] != id {
+				start--
+			}
+			path := append(append([]string(nil), stack[start:]...), id)
+			return &CycleError{Path: path}
+		}
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, e := range g.out[id] {
+			if err := visit(e.To); err != nil {
+				return err
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+
+	if err := visit(startID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
@@ -0,0 +1,244 @@
+// Package graph holds graph algorithms that run in memory on relationships loaded from
+// any repository, so they behave the same for Neo4j and the in-memory store.
+package graph
+
+import (
+	"container/heap"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// Edge is a traversable step from one node to another.
+type Edge struct {
+	RelationshipID string
+	From, To       string
+	Weight         float64
+}
+
+// Graph is an adjacency list of the relationships it was built from.
+type Graph struct {
+	out map[string][]Edge
+}
+
+// New builds a graph from rels. Relationships of types the registry marks as undirected
+// can be followed both ways, all others only from source to target.
+func New(rels []*domain.Relationship, registry *domain.TypeRegistry) *Graph {
+	g := &Graph{out: make(map[string][]Edge)}
+	for _, rel := range rels {
+		directed := true
+		if def, ok := registry.RelationType(rel.Type); ok {
+			directed = def.Directed
+		}
+		for _, targetID := range rel.TargetIDs {
+			g.addEdge(Edge{RelationshipID: rel.ID, From: rel.SourceID, To: targetID, Weight: rel.EffectiveWeight()})
+			if !directed {
+				g.addEdge(Edge{RelationshipID: rel.ID, From: targetID, To: rel.SourceID, Weight: rel.EffectiveWeight()})
+			}
+		}
+	}
+	return g
+}
+
+// NewUndirected builds a graph in which every relationship can be followed both ways.
+func NewUndirected(rels []*domain.Relationship) *Graph {
+	g := &Graph{out: make(map[string][]Edge)}
+	for _, rel := range rels {
+		for _, targetID := range rel.TargetIDs {
+			g.addEdge(Edge{RelationshipID: rel.ID, From: rel.SourceID, To: targetID, Weight: rel.EffectiveWeight()})
+			g.addEdge(Edge{RelationshipID: rel.ID, From: targetID, To: rel.SourceID, Weight: rel.EffectiveWeight()})
+		}
+	}
+	return g
+}
+
+// NewDirected builds a graph in which every relationship is followed only from source
+// to target, whatever its type.
+func NewDirected(rels []*domain.Relationship) *Graph {
+	g := &Graph{out: make(map[string][]Edge)}
+	for _, rel := range rels {
+		for _, targetID := range rel.TargetIDs {
+			g.addEdge(Edge{RelationshipID: rel.ID, From: rel.SourceID, To: targetID, Weight: rel.EffectiveWeight()})
+		}
+	}
+	return g
+}
+
+// AddEdge adds e to the graph.
+func (g *Graph) AddEdge(e Edge) {
+	g.addEdge(e)
+}
+
+func (g *Graph) addEdge(e Edge) {
+	g.out[e.From] = append(g.out[e.From], e)
+}
+
+// Edges returns the edges leaving nodeID in insertion order.
+func (g *Graph) Edges(nodeID string) []Edge {
+	return g.out[nodeID]
+}
+
+// ShortestPath returns the path from fromID to toID with the lowest total weight using
+// Dijkstra's algorithm; Cost is set to that weight. Weights must not be negative. ok is
+// false if toID is unreachable.
+func (g *Graph) ShortestPath(fromID, toID string) (path domain.Path, ok bool) {
+	type step struct {
+		prev  string
+		relID string
+	}
+	dist := map[string]float64{fromID: 0}
+	prev := make(map[string]step)
+	done := make(map[string]bool)
+
+	queue := &priorityQueue{{nodeID: fromID}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(queueItem)
+		if done[item.nodeID] {
+			continue
+		}
+		done[item.nodeID] = true
+		if item.nodeID == toID {
+			break
+		}
+		for _, e := range g.out[item.nodeID] {
+			cost := item.cost + e.Weight
+			if d, seen := dist[e.To]; seen && d <= cost {
+				continue
+			}
+			dist[e.To] = cost
+			prev[e.To] = step{prev: item.nodeID, relID: e.RelationshipID}
+			heap.Push(queue, queueItem{nodeID: e.To, cost: cost})
+		}
+	}
+	if !done[toID] {
+		return domain.Path{}, false
+	}
+
+	path.Cost = dist[toID]
+	for id := toID; id != fromID; id = prev[id].prev {
+		path.NodeIDs = append(path.NodeIDs, id)
+		path.RelationshipIDs = append(path.RelationshipIDs, prev[id].relID)
+	}
+	path.NodeIDs = append(path.NodeIDs, fromID)
+	reverse(path.NodeIDs)
+	reverse(path.RelationshipIDs)
+	return path, true
+}
+
+type queueItem struct {
+	nodeID string
+	cost   float64
+}
+
+// priorityQueue implements heap.Interface ordered by cost, ties broken by node id
+// so that equal-cost paths are found deterministically.
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int { return len(q) }
+func (q priorityQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].nodeID < q[j].nodeID
+}
+func (q priorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(x interface{}) { *q = append(*q, x.(queueItem)) }
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// ShortestPaths returns the paths from fromID to toID with the fewest hops, ignoring
+// weights, using breadth-first search. Paths longer than maxDepth hops are not found.
+// Unless all is set only the first path in edge insertion order is returned. The result
+// is empty if toID is unreachable.
+func (g *Graph) ShortestPaths(fromID, toID string, maxDepth int, all bool) []domain.Path {
+	if fromID == toID {
+		return []domain.Path{{NodeIDs: []string{fromID}}}
+	}
+	type step struct {
+		prev  string
+		relID string
+	}
+	depth := map[string]int{fromID: 0}
+	prev := make(map[string][]step)
+	frontier := []string{fromID}
+	for level := 1; level <= maxDepth && len(frontier) > 0 && len(prev[toID]) == 0; level++ {
+		var next []string
+		for _, id := range frontier {
+			for _, e := range g.out[id] {
+				d, seen := depth[e.To]
+				if !seen {
+					depth[e.To] = level
+					next = append(next, e.To)
+				} else if d != level {
+					continue
+				}
+				prev[e.To] = append(prev[e.To], step{prev: id, relID: e.RelationshipID})
+			}
+		}
+		frontier = next
+	}
+	if len(prev[toID]) == 0 {
+		return nil
+	}
+
+	// Walk the predecessors back from toID, building each path in reverse.
+	var paths []domain.Path
+	var nodes, rels []string
+	var walk func(id string) bool
+	walk = func(id string) bool {
+		nodes = append(nodes, id)
+		defer func() { nodes = nodes[:len(nodes)-1] }()
+		if id == fromID {
+			path := domain.Path{
+				NodeIDs:         append([]string(nil), nodes...),
+				RelationshipIDs: append([]string(nil), rels...),
+			}
+			reverse(path.NodeIDs)
+			reverse(path.RelationshipIDs)
+			paths = append(paths, path)
+			return !all
+		}
+		for _, s := range prev[id] {
+			rels = append(rels, s.relID)
+			done := walk(s.prev)
+			rels = rels[:len(rels)-1]
+			if done {
+				return true
+			}
+		}
+		return false
+	}
+	walk(toID)
+	return paths
+}
+
+// Reachable returns startID and every node reachable from it within maxDepth hops, in
+// breadth-first order.
+func (g *Graph) Reachable(startID string, maxDepth int) []string {
+	seen := map[string]bool{startID: true}
+	order := []string{startID}
+	frontier := []string{startID}
+	for level := 1; level <= maxDepth && len(frontier) > 0; level++ {
+		var next []string
+		for _, id := range frontier {
+			for _, e := range g.out[id] {
+				if !seen[e.To] {
+					seen[e.To] = true
+					order = append(order, e.To)
+					next = append(next, e.To)
+				}
+			}
+		}
+		frontier = next
+	}
+	return order
+}
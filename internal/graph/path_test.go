@@ -0,0 +1,99 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/graph"
+)
+
+func TestShortestPath(t *testing.T) {
+	registry := domain.NewTypeRegistry(nil, nil)
+	rels := []*domain.Relationship{
+		{ID: "ab", SourceID: "a", TargetIDs: []string{"b"}, Type: domain.DependsOn, Weight: 5},
+		{ID: "ac", SourceID: "a", TargetIDs: []string{"c"}, Type: domain.DependsOn},
+		{ID: "cd", SourceID: "c", TargetIDs: []string{"d"}, Type: domain.DependsOn, Weight: 1.5},
+		{ID: "db", SourceID: "d", TargetIDs: []string{"b"}, Type: domain.DependsOn},
+		{ID: "eb", SourceID: "e", TargetIDs: []string{"b"}, Type: domain.RelatedTo},
+	}
+	g := graph.New(rels, registry)
+
+	path, ok := g.ShortestPath("a", "b")
+	assert.True(t, ok, "b should be reachable")
+	assert.Equal(t, []string{"a", "c", "d", "b"}, path.NodeIDs, "The cheaper detour should win")
+	assert.Equal(t, []string{"ac", "cd", "db"}, path.RelationshipIDs)
+	assert.Equal(t, 3.5, path.Cost, "Unset weights should count as 1")
+
+	_, ok = g.ShortestPath("b", "a")
+	assert.False(t, ok, "Directed relationships should only be followed forwards")
+	path, ok = g.ShortestPath("b", "e")
+	assert.True(t, ok, "Undirected relationships should be followed both ways")
+	assert.Equal(t, []string{"eb"}, path.RelationshipIDs)
+
+	path, ok = g.ShortestPath("a", "a")
+	assert.True(t, ok, "A node should reach itself")
+	assert.Equal(t, []string{"a"}, path.NodeIDs)
+	assert.Zero(t, path.Cost)
+}
+
+func TestTopologicalOrder(t *testing.T) {
+	rels := []*domain.Relationship{
+		{ID: "1", SourceID: "closures", TargetIDs: []string{"functions"}},
+		{ID: "2", SourceID: "closures", TargetIDs: []string{"scope"}},
+		{ID: "3", SourceID: "functions", TargetIDs: []string{"basics"}},
+		{ID: "4", SourceID: "scope", TargetIDs: []string{"basics"}},
+		{ID: "5", SourceID: "other", TargetIDs: []string{"closures"}},
+	}
+	order, err := graph.NewDirected(rels).TopologicalOrder("closures")
+	assert.NoError(t, err, "TopologicalOrder should succeed")
+	assert.Equal(t, []string{"basics", "functions", "scope", "closures"}, order,
+		"Prerequisites should come first and unrelated nodes be skipped")
+
+	rels = append(rels, &domain.Relationship{ID: "6", SourceID: "basics", TargetIDs: []string{"closures"}})
+	_, err = graph.NewDirected(rels).TopologicalOrder("scope")
+	var cycle *graph.CycleError
+	assert.ErrorAs(t, err, &cycle, "Cycles should be reported")
+	assert.Equal(t, []string{"basics", "closures", "functions", "basics"}, cycle.Path)
+}
+
+func TestShortestPaths(t *testing.T) {
+	rels := []*domain.Relationship{
+		{ID: "ab", SourceID: "a", TargetIDs: []string{"b"}},
+		{ID: "cb", SourceID: "c", TargetIDs: []string{"b"}},
+		{ID: "bd", SourceID: "b", TargetIDs: []string{"d"}},
+		{ID: "cd", SourceID: "c", TargetIDs: []string{"d"}},
+		{ID: "ac", SourceID: "a", TargetIDs: []string{"c"}, Weight: 10},
+		{ID: "de", SourceID: "d", TargetIDs: []string{"e"}},
+	}
+	g := graph.NewUndirected(rels)
+
+	paths := g.ShortestPaths("a", "d", 6, false)
+	assert.Len(t, paths, 1, "Only one path should be returned")
+	assert.Equal(t, []string{"a", "b", "d"}, paths[0].NodeIDs, "Weights should be ignored")
+	assert.Equal(t, []string{"ab", "bd"}, paths[0].RelationshipIDs)
+
+	paths = g.ShortestPaths("a", "d", 6, true)
+	assert.Len(t, paths, 2, "Every path with the fewest hops should be returned")
+	assert.Equal(t, []string{"a", "c", "d"}, paths[1].NodeIDs)
+
+	paths = g.ShortestPaths("e", "a", 6, false)
+	assert.Equal(t, []string{"e", "d", "b", "a"}, paths[0].NodeIDs, "Relationships should be followed both ways")
+	assert.Empty(t, g.ShortestPaths("e", "a", 2, true), "Paths longer than the depth should not be found")
+	assert.Empty(t, g.ShortestPaths("a", "x", 6, true), "Unknown nodes should not be reached")
+}
+
+func TestReachable(t *testing.T) {
+	rels := []*domain.Relationship{
+		{ID: "ab", SourceID: "a", TargetIDs: []string{"b"}},
+		{ID: "bc", SourceID: "b", TargetIDs: []string{"c"}},
+		{ID: "ad", SourceID: "a", TargetIDs: []string{"d"}},
+		{ID: "ea", SourceID: "e", TargetIDs: []string{"a"}},
+	}
+
+	assert.Equal(t, []string{"a", "b", "d", "c"}, graph.NewDirected(rels).Reachable("a", 2), "Nodes should be returned breadth-first")
+	assert.Equal(t, []string{"a", "b", "d"}, graph.NewDirected(rels).Reachable("a", 1), "Nodes beyond the depth should not be reached")
+	assert.Equal(t, []string{"a"}, graph.NewDirected(rels).Reachable("a", 0))
+	assert.Equal(t, []string{"c", "b", "a", "d", "e"}, graph.NewUndirected(rels).Reachable("c", 6), "Undirected graphs should be walked both ways")
+}
@@ -0,0 +1,56 @@
+package layout
+
+import "math"
+
+// Circular puts the nodes evenly on a circle. Linked nodes are kept next to each other by
+// walking every connected group breadth-first. Previous positions are not used.
+type Circular struct{}
+
+func (Circular) Place(nodes []string, links []Link, _ map[string]Point, width, height float64) map[string]Point {
+	ids := sortedUnique(nodes)
+	positions := make(map[string]Point, len(ids))
+	if len(ids) == 0 {
+		return positions
+	}
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
+	neighbours := make(map[string][]string)
+	for _, l := range links {
+		if known[l.From] && known[l.To] {
+			neighbours[l.From] = append(neighbours[l.From], l.To)
+			neighbours[l.To] = append(neighbours[l.To], l.From)
+		}
+	}
+
+	var order []string
+	seen := make(map[string]bool, len(ids))
+	for _, start := range ids {
+		if seen[start] {
+			continue
+		}
+		seen[start] = true
+		queue := []string{start}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			order = append(order, id)
+			next := sortedUnique(neighbours[id])
+			for _, nb := range next {
+				if !seen[nb] {
+					seen[nb] = true
+					queue = append(queue, nb)
+				}
+			}
+		}
+	}
+
+	center := Point{X: width / 2, Y: height / 2}
+	radius := math.Max(math.Min(width, height)/2-Margin, 0)
+	for i, id := range order {
+		angle := 2*math.Pi*float64(i)/float64(len(order)) - math.Pi/2
+		positions[id] = Point{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+	return positions
+}
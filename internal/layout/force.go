@@ -0,0 +1,132 @@
+package layout
+
+import "math"
+
+// ForceDirected is a Fruchterman-Reingold layout: linked nodes attract each other, all
+// nodes repel each other, and the moves shrink as the layout cools down. Without previous
+// positions nodes start at points derived from their ids. With previous positions the
+// known nodes start where they were and new nodes next to their placed neighbours, and
+// the layout starts cooler, so an added node does not reshuffle the picture.
+type ForceDirected struct {
+	// Iterations of a full layout, DefaultIterations if zero. An incremental layout runs
+	// a quarter of them.
+	Iterations int
+}
+
+// DefaultIterations of a full force-directed layout.
+const DefaultIterations = 200
+
+func (f ForceDirected) Place(nodes []string, links []Link, previous map[string]Point, width, height float64) map[string]Point {
+	ids := sortedUnique(nodes)
+	n := len(ids)
+	positions := make(map[string]Point, n)
+	if n == 0 {
+		return positions
+	}
+	index := make(map[string]int, n)
+	for i, id := range ids {
+		index[id] = i
+	}
+	var edges [][2]int
+	neighbours := make([][]int, n)
+	for _, l := range links {
+		a, ok1 := index[l.From]
+		b, ok2 := index[l.To]
+		if ok1 && ok2 && a != b {
+			edges = append(edges, [2]int{a, b})
+			neighbours[a] = append(neighbours[a], b)
+			neighbours[b] = append(neighbours[b], a)
+		}
+	}
+
+	pos := make([]Point, n)
+	known := make([]bool, n)
+	placed := 0
+	for i, id := range ids {
+		if p, ok := previous[id]; ok {
+			pos[i], known[i] = p, true
+			placed++
+		}
+	}
+	for i, id := range ids {
+		if known[i] {
+			continue
+		}
+		pos[i] = hashPoint(id, width, height)
+		// Start a new node next to the centre of its placed neighbours.
+		var sum Point
+		count := 0
+		for _, j := range neighbours[i] {
+			if known[j] {
+				sum.X += pos[j].X
+				sum.Y += pos[j].Y
+				count++
+			}
+		}
+		if count > 0 {
+			jitter := hashPoint(id, 60, 60)
+			pos[i] = Point{X: sum.X/float64(count) + jitter.X - 30, Y: sum.Y/float64(count) + jitter.Y - 30}
+		}
+	}
+
+	iterations := f.Iterations
+	if iterations <= 0 {
+		iterations = DefaultIterations
+	}
+	temperature := width / 10
+	if placed > 0 {
+		iterations = max(iterations/4, 1)
+		temperature = width / 40
+	}
+	area := math.Max(width-2*Margin, 1) * math.Max(height-2*Margin, 1)
+	k := math.Sqrt(area / float64(n))
+	cooling := temperature / float64(iterations+1)
+
+	disp := make([]Point, n)
+	for iter := 0; iter < iterations; iter++ {
+		for i := range disp {
+			disp[i] = Point{}
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy := pos[i].X-pos[j].X, pos[i].Y-pos[j].Y
+				dist := math.Hypot(dx, dy)
+				if dist < 0.01 {
+					// Separate coincident nodes in a direction that only depends on their order.
+					angle := float64(i*31+j*17) * 0.1
+					dx, dy, dist = math.Cos(angle)*0.01, math.Sin(angle)*0.01, 0.01
+				}
+				force := k * k / dist
+				disp[i].X += dx / dist * force
+				disp[i].Y += dy / dist * force
+				disp[j].X -= dx / dist * force
+				disp[j].Y -= dy / dist * force
+			}
+		}
+		for _, e := range edges {
+			a, b := e[0], e[1]
+			dx, dy := pos[a].X-pos[b].X, pos[a].Y-pos[b].Y
+			dist := math.Max(math.Hypot(dx, dy), 0.01)
+			force := dist * dist / k
+			disp[a].X -= dx / dist * force
+			disp[a].Y -= dy / dist * force
+			disp[b].X += dx / dist * force
+			disp[b].Y += dy / dist * force
+		}
+		for i := range pos {
+			length := math.Hypot(disp[i].X, disp[i].Y)
+			if length > 0 {
+				step := math.Min(length, temperature)
+				pos[i].X += disp[i].X / length * step
+				pos[i].Y += disp[i].Y / length * step
+			}
+			pos[i] = clamp(pos[i], width, height)
+		}
+		temperature -= cooling
+	}
+
+	for i, id := range ids {
+		positions[id] = pos[i]
+	}
+	return positions
+}
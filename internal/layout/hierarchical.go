@@ -0,0 +1,115 @@
+package layout
+
+import (
+	"math"
+	"sort"
+)
+
+// Hierarchical puts nodes in layers so that links point downwards: nodes without incoming
+// links form the top layer and every other node sits one layer below its lowest
+// predecessor. Links closing a cycle are ignored. Within a layer nodes are ordered by the
+// average position of their predecessors to reduce crossings. Previous positions are not
+// used.
+type Hierarchical struct{}
+
+func (Hierarchical) Place(nodes []string, links []Link, _ map[string]Point, width, height float64) map[string]Point {
+	ids := sortedUnique(nodes)
+	n := len(ids)
+	positions := make(map[string]Point, n)
+	if n == 0 {
+		return positions
+	}
+	index := make(map[string]int, n)
+	for i, id := range ids {
+		index[id] = i
+	}
+	out := make([][]int, n)
+	in := make([][]int, n)
+	indegree := make([]int, n)
+	for _, l := range links {
+		a, ok1 := index[l.From]
+		b, ok2 := index[l.To]
+		if ok1 && ok2 && a != b {
+			out[a] = append(out[a], b)
+			in[b] = append(in[b], a)
+			indegree[b]++
+		}
+	}
+
+	// Kahn's algorithm; on a cycle the remaining node with the smallest id is taken next.
+	layer := make([]int, n)
+	done := make([]bool, n)
+	for processed := 0; processed < n; processed++ {
+		next := -1
+		for i := 0; i < n; i++ {
+			if !done[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			for i := 0; i < n; i++ {
+				if !done[i] {
+					next = i
+					break
+				}
+			}
+		}
+		done[next] = true
+		for _, p := range in[next] {
+			if done[p] && p != next {
+				layer[next] = max(layer[next], layer[p]+1)
+			}
+		}
+		for _, s := range out[next] {
+			indegree[s]--
+		}
+	}
+
+	var layers [][]int
+	for i := 0; i < n; i++ {
+		for len(layers) <= layer[i] {
+			layers = append(layers, nil)
+		}
+		layers[layer[i]] = append(layers[layer[i]], i)
+	}
+	order := make([]float64, n)
+	for l, members := range layers {
+		if l > 0 {
+			sort.SliceStable(members, func(a, b int) bool {
+				return barycenter(in[members[a]], order, layer, l) < barycenter(in[members[b]], order, layer, l)
+			})
+		}
+		for pos, i := range members {
+			order[i] = (float64(pos) + 0.5) / float64(len(members))
+		}
+	}
+
+	rowHeight := math.Max(height-2*Margin, 0) / math.Max(float64(len(layers)-1), 1)
+	for l, members := range layers {
+		for _, i := range members {
+			positions[ids[i]] = Point{
+				X: Margin + order[i]*math.Max(width-2*Margin, 0),
+				Y: Margin + float64(l)*rowHeight,
+			}
+		}
+	}
+	return positions
+}
+
+// barycenter is the average relative position of the predecessors in layers above l,
+// 0.5 without any.
+func barycenter(preds []int, order []float64, layer []int, l int) float64 {
+	var sum float64
+	count := 0
+	for _, p := range preds {
+		if layer[p] < l {
+			sum += order[p]
+			count++
+		}
+	}
+	if count == 0 {
+		return 0.5
+	}
+	return sum / float64(count)
+}
@@ -0,0 +1,84 @@
+// Package layout places graph nodes on a plane. Every layout is deterministic: the same
+// nodes and links give the same positions, whatever their order.
+package layout
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Point is a position on the plane.
+type Point struct {
+	X, Y float64
+}
+
+// Link connects two nodes by id. Hierarchical layouts put From above To.
+type Link struct {
+	From, To string
+}
+
+// Layout computes the positions of nodes within a width x height area, keeping a margin
+// free along the borders. previous holds the positions of an earlier run, nil if there is
+// none; layouts that support it change them as little as possible.
+type Layout interface {
+	Place(nodes []string, links []Link, previous map[string]Point, width, height float64) map[string]Point
+}
+
+// Names of the available layouts, as offered in the UI.
+const (
+	ForceDirectedName = "Force-directed"
+	HierarchicalName  = "Hierarchical"
+	CircularName      = "Circular"
+)
+
+// Names lists the available layouts, the default first.
+var Names = []string{ForceDirectedName, HierarchicalName, CircularName}
+
+// ByName returns the layout with the given name, the force-directed one if it is unknown.
+func ByName(name string) Layout {
+	switch name {
+	case HierarchicalName:
+		return Hierarchical{}
+	case CircularName:
+		return Circular{}
+	default:
+		return ForceDirected{}
+	}
+}
+
+// Margin is kept free along the borders of the area.
+const Margin = 50
+
+// sortedUnique returns the node ids sorted, without duplicates.
+func sortedUnique(nodes []string) []string {
+	ids := append([]string(nil), nodes...)
+	sort.Strings(ids)
+	unique := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// hashPoint derives a stable pseudo-random point inside the area from a node id.
+func hashPoint(id string, width, height float64) Point {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+	sum := h.Sum64()
+	fx := float64(sum&0xffffffff) / math.MaxUint32
+	fy := float64(sum>>32) / math.MaxUint32
+	return Point{
+		X: Margin + fx*math.Max(width-2*Margin, 0),
+		Y: Margin + fy*math.Max(height-2*Margin, 0),
+	}
+}
+
+// clamp keeps p inside the area without the margin.
+func clamp(p Point, width, height float64) Point {
+	p.X = math.Min(math.Max(p.X, Margin), math.Max(width-Margin, Margin))
+	p.Y = math.Min(math.Max(p.Y, Margin), math.Max(height-Margin, Margin))
+	return p
+}
@@ -0,0 +1,78 @@
+package layout_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/layout"
+)
+
+var (
+	testNodes = []string{"a", "b", "c", "d", "e", "f"}
+	testLinks = []layout.Link{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}, {"d", "e"}, {"e", "b"}}
+)
+
+func TestForceDirected(t *testing.T) {
+	f := layout.ForceDirected{}
+	first := f.Place(testNodes, testLinks, nil, 800, 600)
+	shuffled := f.Place([]string{"f", "d", "b", "a", "e", "c"}, testLinks, nil, 800, 600)
+	assert.Equal(t, first, shuffled, "The layout should not depend on the node order")
+	assertInside(t, first, 800, 600)
+
+	// Linked nodes should end up closer than an unlinked one.
+	assert.Less(t, distance(first["a"], first["b"]), distance(first["a"], first["f"]))
+	for i, a := range testNodes {
+		for _, b := range testNodes[i+1:] {
+			assert.Greater(t, distance(first[a], first[b]), 20.0, "Nodes %s and %s should not overlap", a, b)
+		}
+	}
+
+	grown := f.Place(append(testNodes, "g"), append(testLinks, layout.Link{From: "g", To: "a"}), first, 800, 600)
+	for _, id := range testNodes {
+		assert.Less(t, distance(first[id], grown[id]), 100.0, "Adding a node should not move %s far", id)
+	}
+	assert.Less(t, distance(grown["g"], grown["a"]), distance(grown["g"], grown["f"]), "A new node should start near its neighbour")
+}
+
+func TestHierarchical(t *testing.T) {
+	positions := layout.Hierarchical{}.Place(testNodes, testLinks, nil, 800, 600)
+	assertInside(t, positions, 800, 600)
+	assert.Less(t, positions["a"].Y, positions["b"].Y, "Links should point downwards")
+	assert.Less(t, positions["b"].Y, positions["d"].Y)
+	assert.Less(t, positions["d"].Y, positions["e"].Y)
+	assert.Equal(t, positions["b"].Y, positions["c"].Y, "Siblings should share a layer")
+	assert.Equal(t, positions["a"].Y, positions["f"].Y, "Unlinked nodes should be in the top layer")
+}
+
+func TestCircular(t *testing.T) {
+	positions := layout.Circular{}.Place(testNodes, testLinks, nil, 800, 600)
+	assertInside(t, positions, 800, 600)
+	for _, id := range testNodes {
+		assert.InDelta(t, 250, distance(positions[id], layout.Point{X: 400, Y: 300}), 1e-9, "Every node should be on the circle")
+	}
+	assert.Equal(t, layout.Point{X: 400, Y: 50}, roundPoint(positions["a"]), "The first node should be at the top")
+}
+
+func TestByName(t *testing.T) {
+	assert.IsType(t, layout.Circular{}, layout.ByName(layout.CircularName))
+	assert.IsType(t, layout.ForceDirected{}, layout.ByName("unknown"), "Unknown names should fall back to the default")
+	assert.Empty(t, layout.ByName(layout.HierarchicalName).Place(nil, nil, nil, 800, 600))
+}
+
+func assertInside(t *testing.T, positions map[string]layout.Point, width, height float64) {
+	t.Helper()
+	for id, p := range positions {
+		assert.True(t, p.X >= layout.Margin-1e-9 && p.X <= width-layout.Margin+1e-9 &&
+			p.Y >= layout.Margin-1e-9 && p.Y <= height-layout.Margin+1e-9, "Node %s should be inside the margin: %v", id, p)
+	}
+}
+
+func distance(a, b layout.Point) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+func roundPoint(p layout.Point) layout.Point {
+	return layout.Point{X: math.Round(p.X), Y: math.Round(p.Y)}
+}
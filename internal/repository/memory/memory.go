@@ -0,0 +1,641 @@
+// Package memory provides an in-memory implementation of usecase.NodeRepository.
+// It mirrors the behaviour of the Neo4j repository and is used in tests and for
+// running the application without a database.
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/graph"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// ErrNotFound is returned when a requested node does not exist.
+var ErrNotFound = errors.New("not found")
+
+var (
+	_ usecase.NodeRepository = (*NodeRepository)(nil)
+	_ usecase.TagRepository  = (*NodeRepository)(nil)
+	_ usecase.TypeRepository = (*NodeRepository)(nil)
+	_ usecase.ViewRepository = (*NodeRepository)(nil)
+)
+
+// NodeRepository is scoped to one workspace. Repositories returned by
+// ForWorkspace share the same data, keyed by workspace name.
+type NodeRepository struct {
+	data      *data
+	workspace domain.Workspace
+}
+
+type data struct {
+	mu         sync.Mutex
+	workspaces map[string]*store
+}
+
+// NewNodeRepository returns an empty repository scoped to the default workspace.
+func NewNodeRepository() *NodeRepository {
+	return &NodeRepository{
+		data:      &data{workspaces: make(map[string]*store)},
+		workspace: domain.Workspace{Name: domain.DefaultWorkspace},
+	}
+}
+
+func (r *NodeRepository) Workspace() domain.Workspace {
+	return r.workspace
+}
+
+// ForWorkspace returns a repository scoped to ws. The Database of ws is ignored.
+func (r *NodeRepository) ForWorkspace(ws domain.Workspace) usecase.NodeRepository {
+	return r.WithWorkspace(ws)
+}
+
+// WithWorkspace is ForWorkspace returning the concrete type.
+func (r *NodeRepository) WithWorkspace(ws domain.Workspace) *NodeRepository {
+	return &NodeRepository{
+		data:      r.data,
+		workspace: ws,
+	}
+}
+
+// ListWorkspaces returns the sorted names of the workspaces that hold nodes.
+func (r *NodeRepository) ListWorkspaces(_ context.Context) ([]string, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	var names []string
+	for name, s := range r.data.workspaces {
+		if len(s.nodes) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// state returns the store of the repository workspace. The caller must hold r.data.mu.
+func (r *NodeRepository) state() *store {
+	s, ok := r.data.workspaces[r.workspace.Name]
+	if !ok {
+		s = newStore()
+		r.data.workspaces[r.workspace.Name] = s
+	}
+	return s
+}
+
+// WithinTx runs fn against a copy of the workspace state.
+// The copy replaces the state when fn succeeds and is discarded otherwise.
+func (r *NodeRepository) WithinTx(_ context.Context, fn func(tx usecase.Tx) error) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	working := r.state().clone()
+	if err := fn(working); err != nil {
+		return err
+	}
+	r.data.workspaces[r.workspace.Name] = working
+	return nil
+}
+
+func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().CreateNode(ctx, node)
+}
+
+func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().GetNodeByID(ctx, id)
+}
+
+func (r *NodeRepository) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().CreateRelationship(ctx, rel)
+}
+
+func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().UpdateNode(ctx, node)
+}
+
+func (r *NodeRepository) DeleteNode(ctx context.Context, id string) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().DeleteNode(ctx, id)
+}
+
+func (r *NodeRepository) DeleteRelationship(ctx context.Context, relationshipID string) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().DeleteRelationship(ctx, relationshipID)
+}
+
+func (r *NodeRepository) UpdateRelationship(ctx context.Context, rel *domain.Relationship) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().UpdateRelationship(ctx, rel)
+}
+
+func (r *NodeRepository) RestoreNode(ctx context.Context, node *domain.Node) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().RestoreNode(ctx, node)
+}
+
+func (r *NodeRepository) RestoreRelationship(ctx context.Context, rel *domain.Relationship) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().RestoreRelationship(ctx, rel)
+}
+
+func (r *NodeRepository) ListNodeRevisions(ctx context.Context, nodeID string) ([]domain.NodeRevision, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().ListNodeRevisions(ctx, nodeID)
+}
+
+func (r *NodeRepository) RestoreNodeRevisions(ctx context.Context, nodeID string, revisions []domain.NodeRevision) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().RestoreNodeRevisions(ctx, nodeID, revisions)
+}
+
+func (r *NodeRepository) SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().SearchNodes(ctx, query, criteria)
+}
+
+func (r *NodeRepository) GetRelationship(ctx context.Context, relationshipID string) (*domain.Relationship, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().GetRelationship(ctx, relationshipID)
+}
+
+func (r *NodeRepository) ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().ListRelationships(ctx, nodeID)
+}
+
+// FindPaths searches the shortest paths breadth-first.
+func (r *NodeRepository) FindPaths(_ context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	s := r.state()
+	if _, ok := s.nodes[fromID]; !ok {
+		return nil, fmt.Errorf("node %s: %w", fromID, ErrNotFound)
+	}
+	if _, ok := s.nodes[toID]; !ok {
+		return nil, fmt.Errorf("node %s: %w", toID, ErrNotFound)
+	}
+	types := make(map[domain.RelationType]bool, len(opts.RelationTypes))
+	for _, t := range opts.RelationTypes {
+		types[t] = true
+	}
+	var rels []*domain.Relationship
+	for _, id := range s.relOrder {
+		if rel := s.rels[id]; len(types) == 0 || types[rel.Type] {
+			rels = append(rels, rel)
+		}
+	}
+	return graph.NewUndirected(rels).ShortestPaths(fromID, toID, opts.Depth(), opts.All), nil
+}
+
+// GetNeighborhood expands the neighbourhood breadth-first.
+func (r *NodeRepository) GetNeighborhood(_ context.Context, id string, depth int, filter domain.NeighborhoodFilter) (*domain.Neighborhood, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	s := r.state()
+	if _, ok := s.nodes[id]; !ok {
+		return nil, fmt.Errorf("node %s: %w", id, ErrNotFound)
+	}
+	types := make(map[domain.RelationType]bool, len(filter.RelationTypes))
+	for _, t := range filter.RelationTypes {
+		types[t] = true
+	}
+	var rels, followed []*domain.Relationship
+	for _, relID := range s.relOrder {
+		rel := s.rels[relID]
+		if len(types) > 0 && !types[rel.Type] {
+			continue
+		}
+		rels = append(rels, rel)
+		if filter.Direction == domain.DirectionIncoming {
+			rel = &domain.Relationship{ID: rel.ID, SourceID: rel.TargetIDs[0], TargetIDs: []string{rel.SourceID}}
+		}
+		followed = append(followed, rel)
+	}
+	g := graph.NewUndirected(followed)
+	if filter.Direction != domain.DirectionBoth {
+		g = graph.NewDirected(followed)
+	}
+
+	n := &domain.Neighborhood{}
+	inside := make(map[string]bool)
+	for _, nodeID := range g.Reachable(id, depth) {
+		inside[nodeID] = true
+		n.Nodes = append(n.Nodes, copyNode(s.nodes[nodeID]))
+	}
+	for _, rel := range rels {
+		if inside[rel.SourceID] && inside[rel.TargetIDs[0]] {
+			n.Relationships = append(n.Relationships, copyRelationship(rel))
+		}
+	}
+	return n, nil
+}
+
+// store holds the repository data. Stored values are never modified in place,
+// every write replaces the entry, so a shallow copy of the maps is a full snapshot.
+type store struct {
+	nodes     map[string]*domain.Node
+	nodeOrder []string
+	rels      map[string]*domain.Relationship
+	relOrder  []string
+	// tags holds every tag name ever attached to a node. Like tag nodes in Neo4j,
+	// a tag stays when the last node using it drops it.
+	tags map[string]bool
+	// nodeTypes and relationTypes hold the user-defined type definitions.
+	nodeTypes     map[domain.NodeType]domain.NodeTypeDef
+	relationTypes map[domain.RelationType]domain.RelationTypeDef
+	// views holds the saved views by name.
+	views map[string]domain.View
+	// revisions holds the former versions of each node by node id, oldest first.
+	revisions map[string][]domain.NodeRevision
+}
+
+func newStore() *store {
+	return &store{
+		nodes:         make(map[string]*domain.Node),
+		rels:          make(map[string]*domain.Relationship),
+		tags:          make(map[string]bool),
+		nodeTypes:     make(map[domain.NodeType]domain.NodeTypeDef),
+		relationTypes: make(map[domain.RelationType]domain.RelationTypeDef),
+		views:         make(map[string]domain.View),
+		revisions:     make(map[string][]domain.NodeRevision),
+	}
+}
+
+func (s *store) clone() *store {
+	c := &store{
+		nodes:     make(map[string]*domain.Node, len(s.nodes)),
+		nodeOrder: append([]string(nil), s.nodeOrder...),
+		rels:      make(map[string]*domain.Relationship, len(s.rels)),
+		relOrder:  append([]string(nil), s.relOrder...),
+		tags:      make(map[string]bool, len(s.tags)),
+
+		nodeTypes:     make(map[domain.NodeType]domain.NodeTypeDef, len(s.nodeTypes)),
+		relationTypes: make(map[domain.RelationType]domain.RelationTypeDef, len(s.relationTypes)),
+		views:         make(map[string]domain.View, len(s.views)),
+		revisions:     make(map[string][]domain.NodeRevision, len(s.revisions)),
+	}
+	for id, n := range s.nodes {
+		c.nodes[id] = n
+	}
+	for id, rel := range s.rels {
+		c.rels[id] = rel
+	}
+	for name := range s.tags {
+		c.tags[name] = true
+	}
+	for name, def := range s.nodeTypes {
+		c.nodeTypes[name] = def
+	}
+	for name, def := range s.relationTypes {
+		c.relationTypes[name] = def
+	}
+	for name, view := range s.views {
+		c.views[name] = view
+	}
+	for id, revisions := range s.revisions {
+		c.revisions[id] = revisions
+	}
+	return c
+}
+
+func (s *store) CreateNode(_ context.Context, node *domain.Node) (string, error) {
+	node.CreatedAt = time.Now()
+	node.UpdatedAt = time.Now()
+
+	stored := copyNode(node)
+	stored.ID = newID()
+	s.nodes[stored.ID] = stored
+	s.nodeOrder = append(s.nodeOrder, stored.ID)
+	s.addTags(stored.Tags)
+	return stored.ID, nil
+}
+
+func (s *store) GetNodeByID(_ context.Context, id string) (*domain.Node, error) {
+	n, ok := s.nodes[id]
+	if !ok {
+		return nil, fmt.Errorf("node %s: %w", id, ErrNotFound)
+	}
+	return copyNode(n), nil
+}
+
+// CreateRelationship links the source to every existing target.
+// Like the Neo4j query, missing nodes are skipped rather than reported.
+func (s *store) CreateRelationship(_ context.Context, rel *domain.Relationship) ([]string, error) {
+	rel.CreatedAt = time.Now()
+
+	if _, ok := s.nodes[rel.SourceID]; !ok {
+		return nil, nil
+	}
+
+	var relIDs []string
+	for _, tID := range rel.TargetIDs {
+		if _, ok := s.nodes[tID]; !ok {
+			continue
+		}
+		stored := &domain.Relationship{
+			ID:          newID(),
+			SourceID:    rel.SourceID,
+			TargetIDs:   []string{tID},
+			Type:        rel.Type,
+			Description: rel.Description,
+			CreatedAt:   rel.CreatedAt,
+			Weight:      rel.Weight,
+			Confidence:  rel.Confidence,
+			ValidFrom:   copyDate(rel.ValidFrom),
+			ValidTo:     copyDate(rel.ValidTo),
+		}
+		s.rels[stored.ID] = stored
+		s.relOrder = append(s.relOrder, stored.ID)
+		relIDs = append(relIDs, stored.ID)
+	}
+	return relIDs, nil
+}
+
+func (s *store) UpdateNode(_ context.Context, node *domain.Node) error {
+	existing, ok := s.nodes[node.ID]
+	if !ok {
+		return fmt.Errorf("node %s: %w", node.ID, ErrNotFound)
+	}
+	node.UpdatedAt = time.Now()
+
+	kept := copyNode(existing)
+	revision := domain.NodeRevision{
+		NodeID:     kept.ID,
+		Title:      kept.Title,
+		Content:    kept.Content,
+		Type:       kept.Type,
+		Tags:       kept.Tags,
+		Properties: kept.Properties,
+		SavedAt:    kept.UpdatedAt,
+	}
+	s.keepRevisions(node.ID, append(append([]domain.NodeRevision(nil), s.revisions[node.ID]...), revision))
+
+	stored := copyNode(node)
+	stored.CreatedAt = existing.CreatedAt
+	s.nodes[node.ID] = stored
+	s.addTags(stored.Tags)
+	return nil
+}
+
+// DeleteNode removes the node together with all of its relationships.
+func (s *store) DeleteNode(_ context.Context, id string) error {
+	if _, ok := s.nodes[id]; !ok {
+		return nil
+	}
+	delete(s.nodes, id)
+	delete(s.revisions, id)
+	s.nodeOrder = removeID(s.nodeOrder, id)
+
+	for relID, rel := range s.rels {
+		if rel.SourceID == id || rel.TargetIDs[0] == id {
+			delete(s.rels, relID)
+			s.relOrder = removeID(s.relOrder, relID)
+		}
+	}
+	return nil
+}
+
+func (s *store) DeleteRelationship(_ context.Context, relationshipID string) error {
+	if _, ok := s.rels[relationshipID]; !ok {
+		return nil
+	}
+	delete(s.rels, relationshipID)
+	s.relOrder = removeID(s.relOrder, relationshipID)
+	return nil
+}
+
+// UpdateRelationship replaces the type, description and attributes of the relationship.
+// The id, endpoints and creation time are kept.
+func (s *store) UpdateRelationship(_ context.Context, rel *domain.Relationship) error {
+	existing, ok := s.rels[rel.ID]
+	if !ok {
+		return fmt.Errorf("relationship %s: %w", rel.ID, ErrNotFound)
+	}
+	stored := copyRelationship(existing)
+	stored.Type = rel.Type
+	stored.Description = rel.Description
+	stored.Weight = rel.Weight
+	stored.Confidence = rel.Confidence
+	stored.ValidFrom = copyDate(rel.ValidFrom)
+	stored.ValidTo = copyDate(rel.ValidTo)
+	s.rels[rel.ID] = stored
+	return nil
+}
+
+// RestoreNode stores the node with its id and timestamps.
+func (s *store) RestoreNode(_ context.Context, node *domain.Node) error {
+	if _, ok := s.nodes[node.ID]; ok {
+		return fmt.Errorf("node %s already exists", node.ID)
+	}
+	stored := copyNode(node)
+	s.nodes[stored.ID] = stored
+	s.nodeOrder = append(s.nodeOrder, stored.ID)
+	s.addTags(stored.Tags)
+	return nil
+}
+
+// ListNodeRevisions returns copies of the revisions of the node, newest first.
+func (s *store) ListNodeRevisions(_ context.Context, nodeID string) ([]domain.NodeRevision, error) {
+	kept := s.revisions[nodeID]
+	revisions := make([]domain.NodeRevision, 0, len(kept))
+	for i := len(kept) - 1; i >= 0; i-- {
+		revisions = append(revisions, copyRevision(kept[i]))
+	}
+	return revisions, nil
+}
+
+// RestoreNodeRevisions adds copies of revisions to those of the node in the order they
+// were saved.
+func (s *store) RestoreNodeRevisions(_ context.Context, nodeID string, revisions []domain.NodeRevision) error {
+	if _, ok := s.nodes[nodeID]; !ok {
+		return fmt.Errorf("node %s: %w", nodeID, ErrNotFound)
+	}
+	kept := append([]domain.NodeRevision(nil), s.revisions[nodeID]...)
+	for _, rev := range revisions {
+		rev = copyRevision(rev)
+		rev.NodeID = nodeID
+		kept = append(kept, rev)
+	}
+	sort.SliceStable(kept, func(i, j int) bool { return kept[i].SavedAt.Before(kept[j].SavedAt) })
+	s.keepRevisions(nodeID, kept)
+	return nil
+}
+
+// keepRevisions stores revisions, oldest first, as those of the node, without the oldest
+// beyond domain.MaxNodeRevisions.
+func (s *store) keepRevisions(nodeID string, revisions []domain.NodeRevision) {
+	if extra := len(revisions) - domain.MaxNodeRevisions; extra > 0 {
+		revisions = revisions[extra:]
+	}
+	s.revisions[nodeID] = revisions
+}
+
+// RestoreRelationship stores the relationship with its id and creation time.
+func (s *store) RestoreRelationship(_ context.Context, rel *domain.Relationship) error {
+	if _, ok := s.rels[rel.ID]; ok {
+		return fmt.Errorf("relationship %s already exists", rel.ID)
+	}
+	for _, id := range []string{rel.SourceID, rel.TargetIDs[0]} {
+		if _, ok := s.nodes[id]; !ok {
+			return fmt.Errorf("node %s: %w", id, ErrNotFound)
+		}
+	}
+	stored := copyRelationship(rel)
+	s.rels[stored.ID] = stored
+	s.relOrder = append(s.relOrder, stored.ID)
+	return nil
+}
+
+func (s *store) GetRelationship(_ context.Context, relationshipID string) (*domain.Relationship, error) {
+	rel, ok := s.rels[relationshipID]
+	if !ok {
+		return nil, fmt.Errorf("relationship %s: %w", relationshipID, ErrNotFound)
+	}
+	return copyRelationship(rel), nil
+}
+
+// ListRelationships returns the relationships in creation order.
+func (s *store) ListRelationships(_ context.Context, nodeID string) ([]*domain.Relationship, error) {
+	var rels []*domain.Relationship
+	for _, id := range s.relOrder {
+		rel := s.rels[id]
+		if nodeID == "" || rel.SourceID == nodeID || rel.TargetIDs[0] == nodeID {
+			rels = append(rels, copyRelationship(rel))
+		}
+	}
+	return rels, nil
+}
+
+// SearchNodes matches nodes case-insensitively with the same criteria as the Neo4j repository.
+func (s *store) SearchNodes(_ context.Context, query, criteria string) ([]*domain.Node, error) {
+	query = strings.ToLower(query)
+
+	var nodes []*domain.Node
+	for _, id := range s.nodeOrder {
+		n := s.nodes[id]
+		inText := strings.Contains(strings.ToLower(n.Title), query) ||
+			strings.Contains(strings.ToLower(n.Content), query)
+
+		var match bool
+		switch criteria {
+		case "Tag":
+			match = hasTagContaining(n, query)
+		case "Title/Content":
+			match = inText
+		case "Property":
+			name, value, found := strings.Cut(query, ":")
+			if !found {
+				name, value = "", query
+			}
+			match = hasPropertyContaining(n, strings.TrimSpace(name), strings.TrimSpace(value))
+		case "All":
+			match = inText || hasTagContaining(n, query) || hasPropertyContaining(n, "", query)
+		default:
+			match = true
+		}
+		if match {
+			nodes = append(nodes, copyNode(n))
+		}
+	}
+	return nodes, nil
+}
+
+func hasTagContaining(n *domain.Node, query string) bool {
+	for _, tag := range n.Tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPropertyContaining reports whether the named property, or any property if name is
+// empty, contains query case-insensitively.
+func hasPropertyContaining(n *domain.Node, name, query string) bool {
+	for key, value := range n.Properties {
+		if (name == "" || key == name) && strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyNode(n *domain.Node) *domain.Node {
+	c := *n
+	c.Tags = append([]string{}, n.Tags...)
+	if n.Properties != nil {
+		c.Properties = make(map[string]string, len(n.Properties))
+		for k, v := range n.Properties {
+			c.Properties[k] = v
+		}
+	}
+	return &c
+}
+
+func copyRevision(rev domain.NodeRevision) domain.NodeRevision {
+	n := copyNode(&domain.Node{Tags: rev.Tags, Properties: rev.Properties})
+	rev.Tags, rev.Properties = n.Tags, n.Properties
+	return rev
+}
+
+func copyRelationship(rel *domain.Relationship) *domain.Relationship {
+	c := *rel
+	c.TargetIDs = append([]string(nil), rel.TargetIDs...)
+	c.ValidFrom = copyDate(rel.ValidFrom)
+	c.ValidTo = copyDate(rel.ValidTo)
+	return &c
+}
+
+func removeID(ids []string, id string) []string {
+	for i, v := range ids {
+		if v == id {
+			return append(ids[:i:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+// newID returns a random UUID in the same format as Neo4j's randomUUID().
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// copyDate copies an optional validity bound, truncated to its day like in Neo4j.
+func copyDate(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	day := domain.DateOf(*t)
+	return &day
+}
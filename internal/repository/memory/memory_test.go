@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestCreateUpdateDeleteNode(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository()
+
+	node := &domain.Node{
+		Title:   "Test Node",
+		Content: "Test content",
+		Type:    domain.Concept,
+		Tags:    []string{"test", "node"},
+	}
+	id, err := repo.CreateNode(ctx, node)
+	assert.NoError(t, err, "CreateNode error should be nil")
+	assert.NotEqual(t, "", id, "Node id should not be empty")
+
+	created, err := repo.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "GetNodeByID error should be nil")
+	assert.Equal(t, node.Title, created.Title, "Titles should match")
+
+	created.Title = "Updated Test Node"
+	err = repo.UpdateNode(ctx, created)
+	assert.NoError(t, err, "UpdateNode error should be nil")
+
+	updated, err := repo.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "GetNodeByID after update error should be nil")
+	assert.Equal(t, "Updated Test Node", updated.Title, "Title should be updated")
+
+	err = repo.DeleteNode(ctx, id)
+	assert.NoError(t, err, "DeleteNode error should be nil")
+
+	_, err = repo.GetNodeByID(ctx, id)
+	assert.ErrorIs(t, err, ErrNotFound, "GetNodeByID should return ErrNotFound for deleted node")
+}
+
+func TestNodeRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository()
+
+	node := &domain.Node{Title: "First", Content: "one", Type: domain.Note, Tags: []string{"go"}}
+	id, err := repo.CreateNode(ctx, node)
+	assert.NoError(t, err, "CreateNode error should be nil")
+	node.ID = id
+	for _, title := range []string{"Second", "Third"} {
+		node.Title = title
+		assert.NoError(t, repo.UpdateNode(ctx, node), "UpdateNode error should be nil")
+	}
+
+	revisions, err := repo.ListNodeRevisions(ctx, id)
+	assert.NoError(t, err, "ListNodeRevisions error should be nil")
+	if assert.Len(t, revisions, 2, "Every update should keep the former version") {
+		assert.Equal(t, "Second", revisions[0].Title, "The newest revision should come first")
+		assert.Equal(t, "First", revisions[1].Title)
+		assert.Equal(t, []string{"go"}, revisions[1].Tags)
+		assert.Equal(t, id, revisions[1].NodeID)
+	}
+	revisions[0].Tags[0] = "changed"
+	revisions, _ = repo.ListNodeRevisions(ctx, id)
+	assert.Equal(t, "go", revisions[0].Tags[0], "Revisions should be returned as copies")
+
+	for i := 0; i < domain.MaxNodeRevisions; i++ {
+		node.Title = fmt.Sprintf("Edit %d", i)
+		assert.NoError(t, repo.UpdateNode(ctx, node), "UpdateNode error should be nil")
+	}
+	revisions, _ = repo.ListNodeRevisions(ctx, id)
+	if assert.Len(t, revisions, domain.MaxNodeRevisions, "Only the newest revisions should be kept") {
+		assert.Equal(t, fmt.Sprintf("Edit %d", domain.MaxNodeRevisions-2), revisions[0].Title)
+		assert.Equal(t, "Third", revisions[len(revisions)-1].Title, "The oldest revisions should be dropped")
+	}
+
+	other := &domain.Node{Title: "Other", Type: domain.Note}
+	otherID, err := repo.CreateNode(ctx, other)
+	assert.NoError(t, err, "CreateNode error should be nil")
+	assert.NoError(t, repo.RestoreNodeRevisions(ctx, otherID, revisions[:2]), "RestoreNodeRevisions error should be nil")
+	restored, _ := repo.ListNodeRevisions(ctx, otherID)
+	if assert.Len(t, restored, 2, "Restored revisions should be kept") {
+		assert.Equal(t, revisions[0].Title, restored[0].Title)
+		assert.Equal(t, otherID, restored[0].NodeID)
+	}
+	assert.ErrorIs(t, repo.RestoreNodeRevisions(ctx, "missing", revisions), ErrNotFound)
+
+	assert.NoError(t, repo.DeleteNode(ctx, id), "DeleteNode error should be nil")
+	revisions, err = repo.ListNodeRevisions(ctx, id)
+	assert.NoError(t, err, "ListNodeRevisions error should be nil")
+	assert.Empty(t, revisions, "Deleting a node should delete its revisions")
+}
+
+func TestSearchNodes(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository()
+
+	_, err := repo.CreateNode(ctx, &domain.Node{Title: "Learn Golang", Content: "Golang tutorial", Type: domain.Concept, Tags: []string{"golang"}})
+	assert.NoError(t, err, "CreateNode for node1 should succeed")
+	_, err = repo.CreateNode(ctx, &domain.Node{Title: "Graph Theory", Content: "Intro", Type: domain.Concept, Tags: []string{"graph", "math"}})
+	assert.NoError(t, err, "CreateNode for node2 should succeed")
+
+	results, err := repo.SearchNodes(ctx, "MATH", "Tag")
+	assert.NoError(t, err, "SearchNodes (Tag) should not error")
+	assert.Len(t, results, 1, "Should find one node for tag 'math'")
+	assert.Equal(t, "Graph Theory", results[0].Title, "Found node should match node2")
+
+	results, err = repo.SearchNodes(ctx, "golang", "Title/Content")
+	assert.NoError(t, err, "SearchNodes (Title/Content) should not error")
+	assert.Len(t, results, 1, "Should find one node containing 'golang'")
+
+	results, err = repo.SearchNodes(ctx, "", "")
+	assert.NoError(t, err, "SearchNodes without criteria should not error")
+	assert.Len(t, results, 2, "Should return every node")
+}
+
+func TestWithinTxCommitsAndRollsBack(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository()
+
+	var sourceID string
+	err := repo.WithinTx(ctx, func(tx usecase.Tx) error {
+		var err error
+		sourceID, err = tx.CreateNode(ctx, &domain.Node{Title: "Source", Type: domain.Concept})
+		if err != nil {
+			return err
+		}
+		targetID, err := tx.CreateNode(ctx, &domain.Node{Title: "Target", Type: domain.Note})
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateRelationship(ctx, &domain.Relationship{SourceID: sourceID, TargetIDs: []string{targetID}, Type: domain.RelatedTo})
+		return err
+	})
+	assert.NoError(t, err, "Committed transaction should not error")
+	_, err = repo.GetNodeByID(ctx, sourceID)
+	assert.NoError(t, err, "Node created in a committed transaction should exist")
+
+	failure := errors.New("link failed")
+	var orphanID string
+	err = repo.WithinTx(ctx, func(tx usecase.Tx) error {
+		var err error
+		orphanID, err = tx.CreateNode(ctx, &domain.Node{Title: "Orphan", Type: domain.Concept})
+		if err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure, "WithinTx should return the error of fn")
+	_, err = repo.GetNodeByID(ctx, orphanID)
+	assert.ErrorIs(t, err, ErrNotFound, "Node created in a rolled back transaction should not exist")
+
+	results, err := repo.SearchNodes(ctx, "", "")
+	assert.NoError(t, err, "SearchNodes should not error")
+	assert.Len(t, results, 2, "Only nodes from the committed transaction should remain")
+}
+
+func TestWorkspacesAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository()
+	research := repo.ForWorkspace(domain.Workspace{Name: "research"})
+
+	id, err := research.CreateNode(ctx, &domain.Node{Title: "Research Note", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode in research workspace should succeed")
+
+	_, err = repo.GetNodeByID(ctx, id)
+	assert.ErrorIs(t, err, ErrNotFound, "Node should not be visible in the default workspace")
+
+	results, err := research.SearchNodes(ctx, "", "")
+	assert.NoError(t, err, "SearchNodes in research workspace should not error")
+	assert.Len(t, results, 1, "Node should be visible in its own workspace")
+
+	names, err := repo.ListWorkspaces(ctx)
+	assert.NoError(t, err, "ListWorkspaces should not error")
+	assert.Equal(t, []string{"research"}, names, "Only workspaces with nodes should be listed")
+}
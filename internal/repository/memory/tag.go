@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func (r *NodeRepository) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().ListTags(ctx)
+}
+
+func (r *NodeRepository) RenameTag(ctx context.Context, oldName, newName string) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().RenameTag(ctx, oldName, newName)
+}
+
+func (r *NodeRepository) MergeTags(ctx context.Context, sources []string, target string) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().MergeTags(ctx, sources, target)
+}
+
+func (r *NodeRepository) DeleteOrphanTags(ctx context.Context) ([]string, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().DeleteOrphanTags(ctx)
+}
+
+func (r *NodeRepository) NodesByTag(ctx context.Context, name string) ([]*domain.Node, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().NodesByTag(ctx, name)
+}
+
+// addTags records the tags together with the ancestors of hierarchical tags.
+func (s *store) addTags(tags []string) {
+	for _, tag := range tags {
+		s.tags[tag] = true
+		for _, ancestor := range domain.TagAncestors(tag) {
+			s.tags[ancestor] = true
+		}
+	}
+}
+
+func (s *store) ListTags(_ context.Context) ([]domain.Tag, error) {
+	counts := make(map[string]int, len(s.tags))
+	for _, n := range s.nodes {
+		for _, tag := range n.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]domain.Tag, 0, len(s.tags))
+	for name := range s.tags {
+		tags = append(tags, domain.Tag{Name: name, Parent: domain.ParentTag(name), Count: counts[name]})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// RenameTag moves oldName and its descendants under newName on the tags and all nodes.
+func (s *store) RenameTag(_ context.Context, oldName, newName string) error {
+	for name := range s.tags {
+		if domain.IsTagWithin(name, oldName) {
+			delete(s.tags, name)
+			s.tags[domain.RebaseTag(name, oldName, newName)] = true
+		}
+	}
+	s.addTags([]string{newName})
+
+	for id, n := range s.nodes {
+		var changed bool
+		tags := make([]string, len(n.Tags))
+		for i, tag := range n.Tags {
+			tags[i] = domain.RebaseTag(tag, oldName, newName)
+			changed = changed || tags[i] != tag
+		}
+		if changed {
+			updated := copyNode(n)
+			updated.Tags = tags
+			s.nodes[id] = updated
+		}
+	}
+	return nil
+}
+
+// MergeTags replaces every source with target on all nodes.
+func (s *store) MergeTags(_ context.Context, sources []string, target string) error {
+	isSource := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		isSource[source] = true
+		delete(s.tags, source)
+	}
+	s.addTags([]string{target})
+
+	for id, n := range s.nodes {
+		var changed bool
+		tags := make([]string, 0, len(n.Tags))
+		for _, tag := range n.Tags {
+			if isSource[tag] {
+				changed = true
+				continue
+			}
+			if tag != target {
+				tags = append(tags, tag)
+			}
+		}
+		if !changed {
+			continue
+		}
+		updated := copyNode(n)
+		updated.Tags = append(tags, target)
+		s.nodes[id] = updated
+	}
+	return nil
+}
+
+// DeleteOrphanTags removes the tags that are not used by any node, directly or through a descendant.
+func (s *store) DeleteOrphanTags(_ context.Context) ([]string, error) {
+	used := make(map[string]bool)
+	for _, n := range s.nodes {
+		for _, tag := range n.Tags {
+			used[tag] = true
+			for _, ancestor := range domain.TagAncestors(tag) {
+				used[ancestor] = true
+			}
+		}
+	}
+
+	var deleted []string
+	for name := range s.tags {
+		if !used[name] {
+			delete(s.tags, name)
+			deleted = append(deleted, name)
+		}
+	}
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+// NodesByTag returns the nodes tagged with name or one of its descendants.
+func (s *store) NodesByTag(_ context.Context, name string) ([]*domain.Node, error) {
+	var nodes []*domain.Node
+	for _, id := range s.nodeOrder {
+		n := s.nodes[id]
+		for _, tag := range n.Tags {
+			if domain.IsTagWithin(tag, name) {
+				nodes = append(nodes, copyNode(n))
+				break
+			}
+		}
+	}
+	return nodes, nil
+}
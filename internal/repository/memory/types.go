@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func (r *NodeRepository) ListNodeTypes(_ context.Context) ([]domain.NodeTypeDef, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	defs := make([]domain.NodeTypeDef, 0, len(r.state().nodeTypes))
+	for _, def := range r.state().nodeTypes {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, nil
+}
+
+func (r *NodeRepository) SaveNodeType(_ context.Context, def domain.NodeTypeDef) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	def.Properties = append([]domain.PropertyDef(nil), def.Properties...)
+	for i, p := range def.Properties {
+		def.Properties[i].Options = append([]string(nil), p.Options...)
+	}
+	r.state().nodeTypes[def.Name] = def
+	return nil
+}
+
+func (r *NodeRepository) DeleteNodeType(_ context.Context, name domain.NodeType) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	delete(r.state().nodeTypes, name)
+	return nil
+}
+
+func (r *NodeRepository) CountNodesOfType(_ context.Context, name domain.NodeType) (int, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	var count int
+	for _, n := range r.state().nodes {
+		if n.Type == name {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *NodeRepository) ListRelationTypes(_ context.Context) ([]domain.RelationTypeDef, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	defs := make([]domain.RelationTypeDef, 0, len(r.state().relationTypes))
+	for _, def := range r.state().relationTypes {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, nil
+}
+
+func (r *NodeRepository) SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().SaveRelationType(ctx, def)
+}
+
+func (r *NodeRepository) DeleteRelationType(ctx context.Context, name domain.RelationType) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	return r.state().DeleteRelationType(ctx, name)
+}
+
+// WithinTypeTx runs fn against a copy of the workspace state, like WithinTx.
+func (r *NodeRepository) WithinTypeTx(_ context.Context, fn func(tx usecase.TypeTx) error) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	working := r.state().clone()
+	if err := fn(working); err != nil {
+		return err
+	}
+	r.data.workspaces[r.workspace.Name] = working
+	return nil
+}
+
+func (s *store) SaveRelationType(_ context.Context, def domain.RelationTypeDef) error {
+	def.Rules.SourceTypes = append([]domain.NodeType(nil), def.Rules.SourceTypes...)
+	def.Rules.TargetTypes = append([]domain.NodeType(nil), def.Rules.TargetTypes...)
+	s.relationTypes[def.Name] = def
+	return nil
+}
+
+func (s *store) DeleteRelationType(_ context.Context, name domain.RelationType) error {
+	delete(s.relationTypes, name)
+	return nil
+}
+
+func (r *NodeRepository) CountRelationshipsOfType(_ context.Context, name domain.RelationType) (int, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	var count int
+	for _, rel := range r.state().rels {
+		if rel.Type == name {
+			count++
+		}
+	}
+	return count, nil
+}
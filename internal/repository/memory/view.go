@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func (r *NodeRepository) ListViews(_ context.Context) ([]domain.View, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	views := make([]domain.View, 0, len(r.state().views))
+	for _, view := range r.state().views {
+		views = append(views, copyView(view))
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views, nil
+}
+
+func (r *NodeRepository) GetView(_ context.Context, name string) (*domain.View, error) {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	view, ok := r.state().views[name]
+	if !ok {
+		return nil, nil
+	}
+	view = copyView(view)
+	return &view, nil
+}
+
+func (r *NodeRepository) SaveView(_ context.Context, view domain.View) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	r.state().views[view.Name] = copyView(view)
+	return nil
+}
+
+func (r *NodeRepository) DeleteView(_ context.Context, name string) error {
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+	delete(r.state().views, name)
+	return nil
+}
+
+// copyView copies the positions, so that callers cannot change a stored view.
+func copyView(view domain.View) domain.View {
+	positions := make(map[string]domain.Position, len(view.Positions))
+	for id, p := range view.Positions {
+		positions[id] = p
+	}
+	view.Positions = positions
+	return view
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// Migration is a versioned change of the database schema or data.
+// Neo4j does not allow schema and data changes in one transaction, so every migration
+// runs in its own transaction and its version is recorded afterwards. Up must therefore
+// be idempotent: it is re-run if the process stops before the version is recorded.
+// Up is passed the workspace whose database is migrated.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, tx neo4j.ManagedTransaction, ws domain.Workspace) error
+}
+
+// Migrations is the ordered list of migrations applied by Migrate.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "unique schema migration versions",
+		Up: runStatements(
+			`CREATE CONSTRAINT schema_migration_version IF NOT EXISTS
+			 FOR (m:SchemaMigration) REQUIRE m.version IS UNIQUE`,
+		),
+	},
+	{
+		Version:     2,
+		Description: "unique node ids and tag names",
+		Up: runStatements(
+			`CREATE CONSTRAINT node_id IF NOT EXISTS FOR (n:Node) REQUIRE n.id IS UNIQUE`,
+			`CREATE CONSTRAINT tag_name IF NOT EXISTS FOR (t:Tag) REQUIRE t.name IS UNIQUE`,
+		),
+	},
+	{
+		Version:     3,
+		Description: "relationship id indexes",
+		Up:          runStatements(relationshipIDIndexes(domain.RelationTypes)...),
+	},
+	{
+		Version:     4,
+		Description: "backfill missing node timestamps and tags",
+		Up: runStatements(
+			`MATCH (n:Node) WHERE n.created_at IS NULL
+			 SET n.created_at = coalesce(n.updated_at, datetime())`,
+			`MATCH (n:Node) WHERE n.updated_at IS NULL
+			 SET n.updated_at = n.created_at`,
+			`MATCH (n:Node) WHERE n.tags IS NULL
+			 OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+			 WITH n, collect(t.name) AS tags
+			 SET n.tags = tags`,
+		),
+	},
+	{
+		Version:     5,
+		Description: "workspace scoped tags and workspace indexes",
+		Up: runStatements(
+			`DROP CONSTRAINT tag_name IF EXISTS`,
+			`CREATE INDEX node_workspace IF NOT EXISTS FOR (n:Node) ON (n.workspace)`,
+			`CREATE CONSTRAINT tag_workspace_name IF NOT EXISTS FOR (t:Tag) REQUIRE (t.workspace, t.name) IS UNIQUE`,
+		),
+	},
+	{
+		Version:     6,
+		Description: "assign nodes and tags without a workspace to the workspace of their database",
+		Up: func(ctx context.Context, tx neo4j.ManagedTransaction, ws domain.Workspace) error {
+			params := map[string]interface{}{"workspace": legacyWorkspace(ws)}
+			return (&txRepository{tx: tx}).runAll(ctx, params,
+				`MATCH (n:Node) WHERE n.workspace IS NULL SET n.workspace = $workspace`,
+				`MATCH (t:Tag) WHERE t.workspace IS NULL SET t.workspace = $workspace`,
+			)
+		},
+	},
+	{
+		Version:     7,
+		Description: "link hierarchical tags to their parents",
+		Up: runStatements(
+			`MATCH (t:Tag) WHERE t.name CONTAINS '` + domain.TagSeparator + `'
+			 WITH t, split(t.name, '` + domain.TagSeparator + `') AS levels
+			 UNWIND range(1, size(levels) - 1) AS i
+			 WITH t.workspace AS workspace,
+			      reduce(s = levels[0], l IN levels[1..i] | s + '` + domain.TagSeparator + `' + l) AS parentName,
+			      reduce(s = levels[0], l IN levels[1..i + 1] | s + '` + domain.TagSeparator + `' + l) AS childName
+			 MERGE (child:Tag {name: childName, workspace: workspace})
+			 MERGE (parent:Tag {name: parentName, workspace: workspace})
+			 MERGE (child)-[:CHILD_OF]->(parent)`,
+		),
+	},
+	{
+		Version:     8,
+		Description: "type registry indexes",
+		Up: runStatements(
+			`CREATE INDEX node_type_def_workspace_name IF NOT EXISTS FOR (d:NodeTypeDef) ON (d.workspace, d.name)`,
+			`CREATE INDEX relation_type_def_workspace_name IF NOT EXISTS FOR (d:RelationTypeDef) ON (d.workspace, d.name)`,
+		),
+	},
+	{
+		Version:     9,
+		Description: "saved view index",
+		Up: runStatements(
+			`CREATE INDEX view_workspace_name IF NOT EXISTS FOR (v:View) ON (v.workspace, v.name)`,
+		),
+	},
+	{
+		// Before migration 5 kept tag names unique per workspace, concurrent MERGEs could
+		// create the same tag twice. The copies are folded into one before 11 forbids them.
+		Version:     10,
+		Description: "merge duplicate tags of a workspace",
+		Up: runStatements(
+			`MATCH (t:Tag) WHERE t.workspace IS NOT NULL
+			 WITH t.workspace AS workspace, t.name AS name, collect(t) AS tags
+			 WHERE size(tags) > 1
+			 WITH head(tags) AS keep, tail(tags) AS copies
+			 UNWIND copies AS copy
+			 CALL {
+			   WITH keep, copy
+			   MATCH (n:Node)-[:HAS_TAG]->(copy)
+			   MERGE (n)-[:HAS_TAG]->(keep)
+			 }
+			 CALL {
+			   WITH keep, copy
+			   MATCH (copy)-[:CHILD_OF]->(parent:Tag)
+			   MERGE (keep)-[:CHILD_OF]->(parent)
+			 }
+			 CALL {
+			   WITH keep, copy
+			   MATCH (child:Tag)-[:CHILD_OF]->(copy)
+			   MERGE (child)-[:CHILD_OF]->(keep)
+			 }
+			 DETACH DELETE copy`,
+		),
+	},
+	{
+		// Databases that ran migration 5 before it created the constraint have a plain index
+		// of the same name, which is replaced.
+		Version:     11,
+		Description: "unique tag names per workspace",
+		Up: runStatements(
+			`DROP CONSTRAINT tag_workspace_name IF EXISTS`,
+			`DROP INDEX tag_workspace_name IF EXISTS`,
+			`CREATE CONSTRAINT tag_workspace_name IF NOT EXISTS FOR (t:Tag) REQUIRE (t.workspace, t.name) IS UNIQUE`,
+		),
+	},
+	{
+		// Migration 6 used to assign the nodes and tags of a workspace with its own database
+		// to the default workspace, which hid them. They are given back to that workspace;
+		// a tag it has since created again absorbs the old copy.
+		Version:     12,
+		Description: "give data of a workspace database back to its workspace",
+		Up: func(ctx context.Context, tx neo4j.ManagedTransaction, ws domain.Workspace) error {
+			if legacyWorkspace(ws) == domain.DefaultWorkspace {
+				return nil
+			}
+			params := map[string]interface{}{"workspace": ws.Name, "default": domain.DefaultWorkspace}
+			return (&txRepository{tx: tx}).runAll(ctx, params,
+				`MATCH (n:Node {workspace: $default}) SET n.workspace = $workspace`,
+				`MATCH (t:Tag {workspace: $default})
+				 WHERE NOT EXISTS { MATCH (:Tag {workspace: $workspace, name: t.name}) }
+				 SET t.workspace = $workspace`,
+				`MATCH (copy:Tag {workspace: $default})
+				 MATCH (keep:Tag {workspace: $workspace, name: copy.name})
+				 CALL {
+				   WITH keep, copy
+				   MATCH (n:Node)-[:HAS_TAG]->(copy)
+				   MERGE (n)-[:HAS_TAG]->(keep)
+				 }
+				 CALL {
+				   WITH keep, copy
+				   MATCH (copy)-[:CHILD_OF]->(parent:Tag)
+				   MERGE (keep)-[:CHILD_OF]->(parent)
+				 }
+				 CALL {
+				   WITH keep, copy
+				   MATCH (child:Tag)-[:CHILD_OF]->(copy)
+				   MERGE (child)-[:CHILD_OF]->(keep)
+				 }
+				 DETACH DELETE copy`,
+			)
+		},
+	},
+}
+
+// legacyWorkspace returns the workspace that owns data written before nodes and tags were
+// stamped with one: the workspace itself if it has its own database, the default
+// workspace in the shared database.
+func legacyWorkspace(ws domain.Workspace) string {
+	if ws.Database == "" || ws.Name == "" {
+		return domain.DefaultWorkspace
+	}
+	return ws.Name
+}
+
+// Migrate applies every migration that is not yet recorded in the database, in version order.
+func (r *NodeRepository) Migrate(ctx context.Context) error {
+	applied, err := r.AppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+	done := make(map[int]bool, len(applied))
+	for _, v := range applied {
+		done[v] = true
+	}
+
+	pending := append([]Migration(nil), Migrations...)
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+
+	for _, m := range pending {
+		if done[m.Version] {
+			continue
+		}
+		if err := r.applyMigration(ctx, m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		log.Printf("Applied migration %d: %s", m.Version, m.Description)
+	}
+	return nil
+}
+
+// AppliedMigrations returns the versions recorded in the database in ascending order.
+func (r *NodeRepository) AppliedMigrations(ctx context.Context) ([]int, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		res, err := tx.tx.Run(ctx, `MATCH (m:SchemaMigration) RETURN m.version AS version ORDER BY version`, nil)
+		if err != nil {
+			return nil, err
+		}
+		var versions []int
+		for res.Next(ctx) {
+			v, _ := res.Record().Get("version")
+			if version, ok := v.(int64); ok {
+				versions = append(versions, int(version))
+			}
+		}
+		return versions, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]int), nil
+}
+
+func (r *NodeRepository) applyMigration(ctx context.Context, m Migration) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, m.Up(ctx, tx.tx, r.workspace)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MERGE (m:SchemaMigration {version: $version})
+			SET m.description = $description,
+			    m.applied_at = datetime()
+		`
+		params := map[string]interface{}{
+			"version":     m.Version,
+			"description": m.Description,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		_, err = res.Consume(ctx)
+		return nil, err
+	})
+	return err
+}
+
+// runStatements returns a migration body that runs the given Cypher statements in order.
+func runStatements(statements ...string) func(context.Context, neo4j.ManagedTransaction, domain.Workspace) error {
+	return func(ctx context.Context, tx neo4j.ManagedTransaction, _ domain.Workspace) error {
+		return (&txRepository{tx: tx}).runAll(ctx, nil, statements...)
+	}
+}
+
+// relationshipIDIndexes returns index statements for the id property of the given relationship types.
+// Relationship property indexes in Neo4j are always bound to a single type.
+func relationshipIDIndexes(types []domain.RelationType) []string {
+	statements := make([]string, 0, len(types))
+	for _, t := range types {
+		statements = append(statements, fmt.Sprintf(
+			"CREATE INDEX rel_%s_id IF NOT EXISTS FOR ()-[r:%s]-() ON (r.id)",
+			strings.ToLower(string(t)), t,
+		))
+	}
+	return statements
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// GetNeighborhood reads the nodes within depth hops of id and then the relationships
+// among them. The nodes are found breadth first, one hop per query, so that the work grows
+// with the nodes reached rather than with the paths to them. Without a type filter every
+// built-in and user-defined relationship type is followed, so that nodes sharing a tag are
+// not neighbours. The relationship types are concatenated into the query, so they must
+// have been validated by the caller.
+func (r *NodeRepository) GetNeighborhood(ctx context.Context, id string, depth int, filter domain.NeighborhoodFilter) (*domain.Neighborhood, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.getNeighborhood(ctx, id, depth, filter)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Neighborhood), nil
+}
+
+func (t *txRepository) getNeighborhood(ctx context.Context, id string, depth int, filter domain.NeighborhoodFilter) (*domain.Neighborhood, error) {
+	if _, err := t.GetNodeByID(ctx, id); err != nil {
+		return nil, err
+	}
+	types := filter.RelationTypes
+	if len(types) == 0 {
+		var err error
+		if types, err = t.relationTypes(ctx); err != nil {
+			return nil, err
+		}
+	}
+	names := make([]string, len(types))
+	for i, relType := range types {
+		names[i] = string(relType)
+	}
+	relPattern := `[:` + strings.Join(names, "|") + `]`
+	switch filter.Direction {
+	case domain.DirectionOutgoing:
+		relPattern = `-` + relPattern + `->`
+	case domain.DirectionIncoming:
+		relPattern = `<-` + relPattern + `-`
+	default:
+		relPattern = `-` + relPattern + `-`
+	}
+
+	// ids lists the nodes reached by distance, each hop ordered by creation.
+	ids := []string{id}
+	frontier := []string{id}
+	params := map[string]interface{}{"workspace": t.workspace}
+	for hop := 1; hop <= depth && len(frontier) > 0; hop++ {
+		params["frontier"] = frontier
+		params["seen"] = ids
+		res, err := t.tx.Run(ctx, `
+			MATCH (a:Node {workspace: $workspace})`+relPattern+`(n:Node {workspace: $workspace})
+			WHERE a.id IN $frontier AND NOT n.id IN $seen
+			RETURN DISTINCT n.id AS id, n.created_at AS created_at
+			ORDER BY created_at, id
+		`, params)
+		if err != nil {
+			return nil, err
+		}
+		frontier = nil
+		for res.Next(ctx) {
+			reached, _ := res.Record().Get("id")
+			frontier = append(frontier, reached.(string))
+		}
+		if err := res.Err(); err != nil {
+			return nil, err
+		}
+		ids = append(ids, frontier...)
+	}
+
+	params["ids"] = ids
+	res, err := t.tx.Run(ctx, `
+		MATCH (n:Node {workspace: $workspace})
+		WHERE n.id IN $ids
+		OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+		RETURN n, collect(distinct t.name) AS tags
+	`, params)
+	if err != nil {
+		return nil, err
+	}
+	found, err := collectNodes(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.Node, len(found))
+	for _, n := range found {
+		byID[n.ID] = n
+	}
+	nodes := make([]*domain.Node, 0, len(ids))
+	for _, nodeID := range ids {
+		if n, ok := byID[nodeID]; ok {
+			nodes = append(nodes, n)
+		}
+	}
+
+	params["types"] = names
+	res, err = t.tx.Run(ctx, `
+		MATCH (a:Node {workspace: $workspace})-[r]->(b:Node {workspace: $workspace})
+		WHERE a.id IN $ids AND b.id IN $ids AND type(r) IN $types
+		RETURN `+relationshipColumns+`
+		ORDER BY created_at, id
+	`, params)
+	if err != nil {
+		return nil, err
+	}
+	n := &domain.Neighborhood{Nodes: nodes}
+	for res.Next(ctx) {
+		n.Relationships = append(n.Relationships, relationshipFromRecord(res.Record()))
+	}
+	return n, res.Err()
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// FindPaths uses shortestPath, or allShortestPaths if opts.All is set. Without a type
+// filter every built-in and user-defined relationship type is followed, which keeps the
+// search away from tag links. The relationship types and the depth are concatenated into
+// the query, so the types must have been validated by the caller.
+func (r *NodeRepository) FindPaths(ctx context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.findPaths(ctx, fromID, toID, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Path), nil
+}
+
+func (t *txRepository) findPaths(ctx context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error) {
+	for _, id := range []string{fromID, toID} {
+		if _, err := t.GetNodeByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	// shortestPath fails on equal start and end nodes.
+	if fromID == toID {
+		return []domain.Path{{NodeIDs: []string{fromID}}}, nil
+	}
+
+	types := opts.RelationTypes
+	if len(types) == 0 {
+		var err error
+		if types, err = t.relationTypes(ctx); err != nil {
+			return nil, err
+		}
+	}
+	names := make([]string, len(types))
+	for i, relType := range types {
+		names[i] = string(relType)
+	}
+	function := "shortestPath"
+	if opts.All {
+		function = "allShortestPaths"
+	}
+
+	query := `
+		MATCH (a:Node {id: $from_id, workspace: $workspace}), (b:Node {id: $to_id, workspace: $workspace})
+		MATCH p = ` + function + `((a)-[:` + strings.Join(names, "|") + `*..` + strconv.Itoa(opts.Depth()) + `]-(b))
+		RETURN [n IN nodes(p) | n.id] AS node_ids, [r IN relationships(p) | r.id] AS rel_ids
+	`
+	params := map[string]interface{}{
+		"from_id":   fromID,
+		"to_id":     toID,
+		"workspace": t.workspace,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	var paths []domain.Path
+	for res.Next(ctx) {
+		nodeIDs, _ := res.Record().Get("node_ids")
+		relIDs, _ := res.Record().Get("rel_ids")
+		paths = append(paths, domain.Path{
+			NodeIDs:         stringList(nodeIDs),
+			RelationshipIDs: stringList(relIDs),
+		})
+	}
+	return paths, res.Err()
+}
+
+// stringList converts a list column to strings, skipping other values.
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
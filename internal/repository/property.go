@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"strings"
+)
+
+// propertyPrefix marks the Neo4j properties holding the custom properties of a node,
+// so that they cannot clash with built-in fields such as title or type.
+const propertyPrefix = "prop_"
+
+// propertyParams returns the custom properties as a map for SET n += $properties.
+// stale lists the prefixed keys currently on the node; those not in properties are
+// set to null, which removes them.
+func propertyParams(properties map[string]string, stale []string) map[string]interface{} {
+	params := make(map[string]interface{}, len(properties)+len(stale))
+	for _, key := range stale {
+		params[key] = nil
+	}
+	for name, value := range properties {
+		params[propertyPrefix+name] = value
+	}
+	return params
+}
+
+// nodeProperties extracts the custom properties from the properties of a Neo4j node.
+func nodeProperties(props map[string]interface{}) map[string]string {
+	var properties map[string]string
+	for key, value := range props {
+		name, ok := strings.CutPrefix(key, propertyPrefix)
+		if !ok {
+			continue
+		}
+		if s, ok := value.(string); ok {
+			if properties == nil {
+				properties = make(map[string]string)
+			}
+			properties[name] = s
+		}
+	}
+	return properties
+}
+
+// propertyKeys returns the prefixed custom property keys currently stored on the node.
+func (t *txRepository) propertyKeys(ctx context.Context, id string) ([]string, error) {
+	query := `
+		MATCH (n:Node {id: $id, workspace: $workspace})
+		RETURN [k IN keys(n) WHERE k STARTS WITH $prefix] AS keys
+	`
+	params := map[string]interface{}{
+		"id":        id,
+		"workspace": t.workspace,
+		"prefix":    propertyPrefix,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for res.Next(ctx) {
+		values, _ := res.Record().Get("keys")
+		for _, k := range values.([]interface{}) {
+			keys = append(keys, k.(string))
+		}
+	}
+	return keys, res.Err()
+}
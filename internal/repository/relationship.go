@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// relationshipColumns returns the columns read by collectRelationships for the relationship r.
+const relationshipColumns = `
+	r.id AS id, startNode(r).id AS source_id, endNode(r).id AS target_id,
+	type(r) AS type, r.description AS description, r.created_at AS created_at,
+	r.weight AS weight, r.confidence AS confidence, r.valid_from AS valid_from, r.valid_to AS valid_to`
+
+func (r *NodeRepository) GetRelationship(ctx context.Context, relationshipID string) (*domain.Relationship, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.GetRelationship(ctx, relationshipID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.Relationship), nil
+}
+
+func (r *NodeRepository) ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.ListRelationships(ctx, nodeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*domain.Relationship), nil
+}
+
+func (r *NodeRepository) UpdateRelationship(ctx context.Context, rel *domain.Relationship) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.UpdateRelationship(ctx, rel)
+	})
+	return err
+}
+
+// UpdateRelationship sets the description and attributes of the relationship. Neo4j cannot
+// change the type of a relationship, so a type change creates a copy of the relationship,
+// including its id and creation time, under the new type and deletes the original.
+// The caller must have validated rel.Type, it is used as relationship type in the query.
+func (t *txRepository) UpdateRelationship(ctx context.Context, rel *domain.Relationship) error {
+	existing, err := t.GetRelationship(ctx, rel.ID)
+	if err != nil {
+		return err
+	}
+	types, err := t.relationTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	set := `
+		r.description = $description,
+		r.weight = $weight,
+		r.confidence = $confidence,
+		r.valid_from = date($valid_from),
+		r.valid_to = date($valid_to)`
+	query := `
+		CALL {
+			` + relationshipByIDUnion(types) + `
+		}
+		SET ` + set
+	if existing.Type != rel.Type {
+		query = `
+			CALL {
+				` + relationshipByIDUnion(types) + `
+			}
+			WITH r AS old, startNode(r) AS source, endNode(r) AS target
+			CREATE (source)-[r:` + string(rel.Type) + `]->(target)
+			SET r = properties(old),` + set + `
+			DELETE old`
+	}
+	params := map[string]interface{}{
+		"id":          rel.ID,
+		"workspace":   t.workspace,
+		"description": rel.Description,
+		"weight":      optionalFloat(rel.Weight),
+		"confidence":  optionalFloat(rel.Confidence),
+		"valid_from":  dateParam(rel.ValidFrom),
+		"valid_to":    dateParam(rel.ValidTo),
+	}
+	return t.runAll(ctx, params, query)
+}
+
+func (t *txRepository) GetRelationship(ctx context.Context, relationshipID string) (*domain.Relationship, error) {
+	types, err := t.relationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		CALL {
+			` + relationshipByIDUnion(types) + `
+		}
+		RETURN ` + relationshipColumns
+	params := map[string]interface{}{
+		"id":        relationshipID,
+		"workspace": t.workspace,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	record, err := res.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return relationshipFromRecord(record), nil
+}
+
+// ListRelationships only returns relationships between nodes, so tag links are skipped.
+func (t *txRepository) ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error) {
+	query := `
+		MATCH (:Node {workspace: $workspace})-[r]->(:Node {workspace: $workspace})
+		RETURN ` + relationshipColumns + `
+		ORDER BY created_at, id
+	`
+	if nodeID != "" {
+		query = `
+			MATCH (:Node {id: $node_id, workspace: $workspace})-[r]-(:Node {workspace: $workspace})
+			WITH DISTINCT r
+			RETURN ` + relationshipColumns + `
+			ORDER BY created_at, id
+		`
+	}
+	params := map[string]interface{}{
+		"node_id":   nodeID,
+		"workspace": t.workspace,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	var rels []*domain.Relationship
+	for res.Next(ctx) {
+		rels = append(rels, relationshipFromRecord(res.Record()))
+	}
+	return rels, res.Err()
+}
+
+// relationshipFromRecord reads a relationship from the columns of relationshipColumns.
+func relationshipFromRecord(record *neo4j.Record) *domain.Relationship {
+	id, _ := record.Get("id")
+	sourceID, _ := record.Get("source_id")
+	targetID, _ := record.Get("target_id")
+	relType, _ := record.Get("type")
+	description, _ := record.Get("description")
+	createdAt, _ := record.Get("created_at")
+	weight, _ := record.Get("weight")
+	confidence, _ := record.Get("confidence")
+	validFrom, _ := record.Get("valid_from")
+	validTo, _ := record.Get("valid_to")
+
+	rel := &domain.Relationship{
+		ID:          stringOrEmpty(id),
+		SourceID:    stringOrEmpty(sourceID),
+		TargetIDs:   []string{stringOrEmpty(targetID)},
+		Type:        domain.RelationType(stringOrEmpty(relType)),
+		Description: stringOrEmpty(description),
+		ValidFrom:   dateOrNil(validFrom),
+		ValidTo:     dateOrNil(validTo),
+	}
+	rel.Weight, _ = weight.(float64)
+	rel.Confidence, _ = confidence.(float64)
+	if created, ok := createdAt.(time.Time); ok {
+		rel.CreatedAt = created
+	}
+	return rel
+}
+
+// dateParam formats an optional validity bound as a parameter for date().
+func dateParam(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(domain.PropertyDateLayout)
+}
+
+func dateOrNil(v interface{}) *time.Time {
+	d, ok := v.(neo4j.Date)
+	if !ok {
+		return nil
+	}
+	t := d.Time()
+	return &t
+}
+
+// optionalFloat maps the zero value to nil, so unset weights and confidences are not stored.
+func optionalFloat(f float64) interface{} {
+	if f == 0 {
+		return nil
+	}
+	return f
+}
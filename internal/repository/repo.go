@@ -10,20 +10,58 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
 	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
 )
 
 type NodeRepository struct {
-	driver neo4j.DriverWithContext
+	driver    neo4j.DriverWithContext
+	workspace domain.Workspace
 }
 
+// NewNodeRepository returns a repository scoped to the default workspace.
 func NewNodeRepository(driver neo4j.DriverWithContext) *NodeRepository {
 	return &NodeRepository{
-		driver: driver,
+		driver:    driver,
+		workspace: domain.Workspace{Name: domain.DefaultWorkspace},
 	}
 }
 
-func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+// txRepository runs repository operations inside an already open managed transaction.
+// Every query is restricted to nodes and tags whose workspace property equals workspace.
+type txRepository struct {
+	tx        neo4j.ManagedTransaction
+	workspace string
+}
+
+func (r *NodeRepository) Workspace() domain.Workspace {
+	return r.workspace
+}
+
+// ForWorkspace returns a repository that shares the driver but is scoped to ws.
+func (r *NodeRepository) ForWorkspace(ws domain.Workspace) usecase.NodeRepository {
+	return r.WithWorkspace(ws)
+}
+
+// WithWorkspace is ForWorkspace returning the concrete type, so callers can still run migrations.
+func (r *NodeRepository) WithWorkspace(ws domain.Workspace) *NodeRepository {
+	return &NodeRepository{
+		driver:    r.driver,
+		workspace: ws,
+	}
+}
+
+// newSession opens a session in the given access mode on the workspace database.
+// An empty database name selects the server's default database.
+func (r *NodeRepository) newSession(ctx context.Context, mode neo4j.AccessMode) neo4j.SessionWithContext {
+	return r.driver.NewSession(ctx, neo4j.SessionConfig{
+		AccessMode:   mode,
+		DatabaseName: r.workspace.Database,
+	})
+}
+
+// write executes work in a write transaction of a new session.
+func (r *NodeRepository) write(ctx context.Context, work func(tx *txRepository) (interface{}, error)) (interface{}, error) {
+	session := r.newSession(ctx, neo4j.AccessModeWrite)
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
 		err := session.Close(ctx)
 		if err != nil {
@@ -31,48 +69,39 @@ func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (str
 		}
 	}(session, ctx)
 
-	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		node.CreatedAt = time.Now()
-		node.UpdatedAt = time.Now()
-
-		query := `
-			CREATE (n:Node {
-    			id: randomUUID(),
-    			title: $title,
-    			content: $content,
-   			 	type: $type,
-    			created_at: datetime($created_at),
-    			updated_at: datetime($updated_at),
-    			tags: $tags
-			})
-			SET n:` + string(node.Type) + `
-				FOREACH (tag IN $tags | MERGE (t:Tag {name: tag}) MERGE (n)-[:HAS_TAG]->(t))
-				RETURN n.id as id
-		`
-
-		params := map[string]interface{}{
-			"title":      node.Title,
-			"content":    node.Content,
-			"type":       string(node.Type),
-			"created_at": node.CreatedAt.Format(time.RFC3339),
-			"updated_at": node.UpdatedAt.Format(time.RFC3339),
-			"tags":       node.Tags,
-		}
+	return session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return work(&txRepository{tx: tx, workspace: r.workspace.Name})
+	})
+}
 
-		result, err := tx.Run(ctx, query, params)
+// read executes work in a read transaction of a new session.
+func (r *NodeRepository) read(ctx context.Context, work func(tx *txRepository) (interface{}, error)) (interface{}, error) {
+	session := r.newSession(ctx, neo4j.AccessModeRead)
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		err := session.Close(ctx)
 		if err != nil {
-			return nil, err
+			log.Fatal(err)
 		}
+	}(session, ctx)
 
-		record, err := result.Single(ctx)
-		if err != nil {
-			return nil, err
-		}
+	return session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return work(&txRepository{tx: tx, workspace: r.workspace.Name})
+	})
+}
 
-		id, _ := record.Get("id")
-		return id, nil
+// WithinTx runs fn in a single write transaction.
+// The driver retries fn on transient errors, so fn may be called more than once.
+func (r *NodeRepository) WithinTx(ctx context.Context, fn func(tx usecase.Tx) error) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, fn(tx)
 	})
+	return err
+}
 
+func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
+	result, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.CreateNode(ctx, node)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -81,331 +110,470 @@ func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (str
 }
 
 func (r *NodeRepository) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer func(session neo4j.SessionWithContext, ctx context.Context) {
-		err := session.Close(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(session, ctx)
+	result, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.CreateRelationship(ctx, rel)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		rel.CreatedAt = time.Now()
-
-		query := `
-			MATCH (source:Node {id: $source_id})
-			UNWIND $target_ids AS tID
-			MATCH (target:Node {id: tID})
-			CREATE (source)-[r:` + string(rel.Type) + ` {
-				id: randomUUID(),
-				description: $description,
-				created_at: datetime($created_at)
-			}]->(target)
-			RETURN collect(r.id) as ids
-		`
+	return result.([]string), nil
+}
 
-		params := map[string]interface{}{
-			"source_id":   rel.SourceID,
-			"target_ids":  rel.TargetIDs,
-			"description": rel.Description,
-			"created_at":  rel.CreatedAt.Format(time.RFC3339),
-		}
+func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.GetNodeByID(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		cyRes, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	return result.(*domain.Node), nil
+}
+
+func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.UpdateNode(ctx, node)
+	})
 
-		record, err := cyRes.Single(ctx)
+	return err
+}
+
+func (r *NodeRepository) DeleteNode(ctx context.Context, id string) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.DeleteNode(ctx, id)
+	})
+
+	return err
+}
+
+func (r *NodeRepository) DeleteRelationship(ctx context.Context, relationshipID string) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.DeleteRelationship(ctx, relationshipID)
+	})
+
+	return err
+}
+
+// ListWorkspaces returns the distinct workspace names found on nodes of the workspace database.
+func (r *NodeRepository) ListWorkspaces(ctx context.Context) ([]string, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		res, err := tx.tx.Run(ctx, `
+			MATCH (n:Node)
+			WHERE n.workspace IS NOT NULL
+			RETURN DISTINCT n.workspace AS workspace
+			ORDER BY workspace
+		`, nil)
 		if err != nil {
 			return nil, err
 		}
-
-		idsVal, _ := record.Get("ids")
-		idsSlice, ok := idsVal.([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("unexpected type for 'ids' column")
-		}
-
-		var relIDs []string
-		for _, v := range idsSlice {
-			if s, ok := v.(string); ok {
-				relIDs = append(relIDs, s)
+		var names []string
+		for res.Next(ctx) {
+			if name, ok := res.Record().Values[0].(string); ok {
+				names = append(names, name)
 			}
 		}
-		return relIDs, nil
+		return names, res.Err()
 	})
-
 	if err != nil {
 		return nil, err
 	}
-
 	return result.([]string), nil
 }
 
-func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer func(session neo4j.SessionWithContext, ctx context.Context) {
-		err := session.Close(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(session, ctx)
-
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (n:Node {id: $id})
-			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
-			RETURN n.id as id, n.title as title, n.content as content, n.type as type, 
-				   n.created_at as created_at, n.updated_at as updated_at, collect(t.name) as tags
-		`
+// SearchNodes searches for nodes based on a query and criteria.
+// Criteria can be "Tag", "Title/Content", "Property", or "All".
+// A "Property" query is either a value or "name:value" to search a single property.
+func (r *NodeRepository) SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.SearchNodes(ctx, query, criteria)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*domain.Node), nil
+}
 
-		params := map[string]interface{}{
-			"id": id,
-		}
+func (t *txRepository) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
+	node.CreatedAt = time.Now()
+	node.UpdatedAt = time.Now()
+
+	query := `
+		CREATE (n:Node {
+			id: randomUUID(),
+			workspace: $workspace,
+			title: $title,
+			content: $content,
+			type: $type,
+			created_at: datetime($created_at),
+			updated_at: datetime($updated_at),
+			tags: $tags
+		})
+		SET n:` + string(node.Type) + `, n += $properties
+			FOREACH (tag IN $tags | MERGE (t:Tag {name: tag, workspace: $workspace}) MERGE (n)-[:HAS_TAG]->(t))
+			` + linkTagParents + `
+			RETURN n.id as id
+	`
 
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	params := map[string]interface{}{
+		"workspace":  t.workspace,
+		"title":      node.Title,
+		"content":    node.Content,
+		"type":       string(node.Type),
+		"created_at": node.CreatedAt.Format(time.RFC3339),
+		"updated_at": node.UpdatedAt.Format(time.RFC3339),
+		"tags":       node.Tags,
+		"tag_links":  tagLinks(node.Tags),
+		"properties": propertyParams(node.Properties, nil),
+	}
 
-		record, err := result.Single(ctx)
-		if err != nil {
-			return nil, err
-		}
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return "", err
+	}
 
-		node := &domain.Node{}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return "", err
+	}
 
-		idVal, _ := record.Get("id")
-		node.ID = idVal.(string)
+	id, _ := record.Get("id")
+	return id.(string), nil
+}
 
-		titleVal, _ := record.Get("title")
-		node.Title = titleVal.(string)
+func (t *txRepository) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
+	rel.CreatedAt = time.Now()
+
+	query := `
+		MATCH (source:Node {id: $source_id, workspace: $workspace})
+		UNWIND $target_ids AS tID
+		MATCH (target:Node {id: tID, workspace: $workspace})
+		CREATE (source)-[r:` + string(rel.Type) + ` {
+			id: randomUUID(),
+			description: $description,
+			created_at: datetime($created_at),
+			weight: $weight,
+			confidence: $confidence,
+			valid_from: date($valid_from),
+			valid_to: date($valid_to)
+		}]->(target)
+		RETURN collect(r.id) as ids
+	`
 
-		contentVal, _ := record.Get("content")
-		node.Content = contentVal.(string)
+	params := map[string]interface{}{
+		"workspace":   t.workspace,
+		"source_id":   rel.SourceID,
+		"target_ids":  rel.TargetIDs,
+		"description": rel.Description,
+		"created_at":  rel.CreatedAt.Format(time.RFC3339),
+		"weight":      optionalFloat(rel.Weight),
+		"confidence":  optionalFloat(rel.Confidence),
+		"valid_from":  dateParam(rel.ValidFrom),
+		"valid_to":    dateParam(rel.ValidTo),
+	}
 
-		nodeType, _ := record.Get("type")
-		node.Type = domain.NodeType(nodeType.(string))
+	cyRes, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
 
-		createdAt, _ := record.Get("created_at")
-		updatedAt, _ := record.Get("updated_at")
+	record, err := cyRes.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		node.CreatedAt = createdAt.(time.Time)
-		node.UpdatedAt = updatedAt.(time.Time)
+	idsVal, _ := record.Get("ids")
+	idsSlice, ok := idsVal.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for 'ids' column")
+	}
 
-		tags, _ := record.Get("tags")
-		for _, tag := range tags.([]interface{}) {
-			node.Tags = append(node.Tags, tag.(string))
+	var relIDs []string
+	for _, v := range idsSlice {
+		if s, ok := v.(string); ok {
+			relIDs = append(relIDs, s)
 		}
+	}
+	return relIDs, nil
+}
 
-		return node, nil
-	})
+func (t *txRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
+	query := `
+		MATCH (n:Node {id: $id, workspace: $workspace})
+		OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+		RETURN n.id as id, n.title as title, n.content as content, n.type as type,
+			   n.created_at as created_at, n.updated_at as updated_at, collect(t.name) as tags,
+			   properties(n) as props
+	`
 
+	params := map[string]interface{}{
+		"id":        id,
+		"workspace": t.workspace,
+	}
+
+	result, err := t.tx.Run(ctx, query, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.(*domain.Node), nil
-}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer func(session neo4j.SessionWithContext, ctx context.Context) {
-		if err := session.Close(ctx); err != nil {
-			log.Fatal(err)
-		}
-	}(session, ctx)
+	node := &domain.Node{}
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		node.UpdatedAt = time.Now()
-
-		query := `
-			MATCH (n:Node {id: $id})
-			SET n.title = $title,
-			    n.content = $content,
-			    n.type = $type,
-			    n.updated_at = datetime($updated_at)
-			WITH n
-			OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
-			DELETE r
-			WITH DISTINCT n
-			LIMIT 1
-			FOREACH (tag IN $tags |
-				MERGE (t:Tag {name: tag})
-				MERGE (n)-[:HAS_TAG]->(t)
-			)
-			RETURN n
-		`
+	idVal, _ := record.Get("id")
+	node.ID = idVal.(string)
 
-		params := map[string]interface{}{
-			"id":         node.ID,
-			"title":      node.Title,
-			"content":    node.Content,
-			"type":       string(node.Type),
-			"updated_at": node.UpdatedAt.Format(time.RFC3339),
-			"tags":       node.Tags,
-		}
+	titleVal, _ := record.Get("title")
+	node.Title = titleVal.(string)
 
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	contentVal, _ := record.Get("content")
+	node.Content = contentVal.(string)
 
-		_, err = result.Single(ctx)
-		return nil, err
-	})
+	nodeType, _ := record.Get("type")
+	node.Type = domain.NodeType(nodeType.(string))
 
-	return err
+	createdAt, _ := record.Get("created_at")
+	updatedAt, _ := record.Get("updated_at")
+
+	node.CreatedAt = createdAt.(time.Time)
+	node.UpdatedAt = updatedAt.(time.Time)
+
+	tags, _ := record.Get("tags")
+	for _, tag := range tags.([]interface{}) {
+		node.Tags = append(node.Tags, tag.(string))
+	}
+
+	props, _ := record.Get("props")
+	node.Properties = nodeProperties(props.(map[string]interface{}))
+
+	return node, nil
 }
 
-func (r *NodeRepository) DeleteNode(ctx context.Context, id string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer func(session neo4j.SessionWithContext, ctx context.Context) {
-		err := session.Close(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(session, ctx)
+// UpdateNode keeps the current version of the node as a NodeRevision linked by a
+// HAS_REVISION relationship before changing it, and deletes the oldest revisions beyond
+// domain.MaxNodeRevisions.
+func (t *txRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
+	node.UpdatedAt = time.Now()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (n:Node {id: $id})
-			DETACH DELETE n
-		`
+	stale, err := t.propertyKeys(ctx, node.ID)
+	if err != nil {
+		return err
+	}
 
-		params := map[string]interface{}{
-			"id": id,
-		}
+	query := `
+		MATCH (n:Node {id: $id, workspace: $workspace})
+		CREATE (n)-[:HAS_REVISION]->(rev:NodeRevision)
+		SET rev = properties(n), rev.node_id = n.id
+		REMOVE rev.id
+		WITH n
+		OPTIONAL MATCH (n)-[:HAS_REVISION]->(kept:NodeRevision)
+		WITH n, kept
+		ORDER BY kept.updated_at DESC
+		WITH n, collect(kept)[$max_revisions..] AS pruned
+		FOREACH (old IN pruned | DETACH DELETE old)
+		WITH n
+		SET n.title = $title,
+		    n.content = $content,
+		    n.type = $type,
+		    n.tags = $tags,
+		    n.updated_at = datetime($updated_at),
+		    n += $properties
+		WITH n
+		OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
+		DELETE r
+		WITH DISTINCT n
+		LIMIT 1
+		FOREACH (tag IN $tags |
+			MERGE (t:Tag {name: tag, workspace: $workspace})
+			MERGE (n)-[:HAS_TAG]->(t)
+		)
+		` + linkTagParents + `
+		RETURN n
+	`
 
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	params := map[string]interface{}{
+		"id":            node.ID,
+		"workspace":     t.workspace,
+		"title":         node.Title,
+		"content":       node.Content,
+		"type":          string(node.Type),
+		"updated_at":    node.UpdatedAt.Format(time.RFC3339),
+		"tags":          node.Tags,
+		"tag_links":     tagLinks(node.Tags),
+		"properties":    propertyParams(node.Properties, stale),
+		"max_revisions": domain.MaxNodeRevisions,
+	}
 
-		_, err = result.Consume(ctx)
-		return nil, err
-	})
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
 
+	_, err = result.Single(ctx)
 	return err
 }
 
-func (r *NodeRepository) DeleteRelationship(ctx context.Context, relationshipID string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer func(session neo4j.SessionWithContext, ctx context.Context) {
-		err := session.Close(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-	}(session, ctx)
+// DeleteNode deletes the node with its relationships and revisions.
+func (t *txRepository) DeleteNode(ctx context.Context, id string) error {
+	query := `
+		MATCH (n:Node {id: $id, workspace: $workspace})
+		OPTIONAL MATCH (n)-[:HAS_REVISION]->(rev:NodeRevision)
+		DETACH DELETE n, rev
+	`
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH ()-[r {id: $id}]-()
-			DELETE r
-		`
-		params := map[string]interface{}{
-			"id": relationshipID,
-		}
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+	params := map[string]interface{}{
+		"id":        id,
+		"workspace": t.workspace,
+	}
 
-		_, err = result.Consume(ctx)
-		return nil, err
-	})
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
 
+	_, err = result.Consume(ctx)
 	return err
 }
 
-// SearchNodes searches for nodes based on a query and criteria.
-// Criteria can be "Tag", "Title/Content", or "All".
-func (r *NodeRepository) SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error) {
+// DeleteRelationship looks the relationship up once per known type, built-in or
+// user-defined, so that every branch can use the relationship id index of its type.
+func (t *txRepository) DeleteRelationship(ctx context.Context, relationshipID string) error {
+	types, err := t.relationTypes(ctx)
+	if err != nil {
+		return err
+	}
+	query := `
+		CALL {
+			` + relationshipByIDUnion(types) + `
+		}
+		DELETE r
+	`
+	params := map[string]interface{}{
+		"id":        relationshipID,
+		"workspace": t.workspace,
+	}
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+
+	_, err = result.Consume(ctx)
+	return err
+}
+
+func (t *txRepository) SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error) {
 	// Convert query to lowercase for case-insensitive search.
 	query = strings.ToLower(query)
 	params := map[string]interface{}{
-		"query": query,
+		"query":     query,
+		"workspace": t.workspace,
+		"prefix":    propertyPrefix,
 	}
 	var cypher string
 	switch criteria {
 	case "Tag":
 		cypher = `
-			MATCH (t:Tag)
+			MATCH (t:Tag {workspace: $workspace})
 			WHERE toLower(t.name) =~ ('.*' + $query + '.*')
-			MATCH (n:Node)-[:HAS_TAG]->(t)
+			MATCH (n:Node {workspace: $workspace})-[:HAS_TAG]->(t)
 			RETURN n, collect(distinct t.name) as tags
 		`
 	case "Title/Content":
 		cypher = `
-			MATCH (n:Node)
+			MATCH (n:Node {workspace: $workspace})
 			WHERE toLower(n.title) CONTAINS $query OR toLower(n.content) CONTAINS $query
 			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
 			RETURN n, collect(distinct t.name) as tags
 		`
+	case "Property":
+		name, value, found := strings.Cut(query, ":")
+		if !found {
+			name, value = "", query
+		}
+		params["name"] = strings.TrimSpace(name)
+		params["query"] = strings.TrimSpace(value)
+		cypher = `
+			MATCH (n:Node {workspace: $workspace})
+			WHERE any(k IN keys(n) WHERE k STARTS WITH $prefix
+				AND ($name = '' OR k = $prefix + $name)
+				AND toLower(n[k]) CONTAINS $query)
+			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+			RETURN n, collect(distinct t.name) as tags
+		`
 	case "All":
 		cypher = `
-			MATCH (n:Node)
+			MATCH (n:Node {workspace: $workspace})
 			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
 			WITH n, collect(distinct t.name) as tags
-			WHERE toLower(n.title) CONTAINS $query 
-			   OR toLower(n.content) CONTAINS $query 
+			WHERE toLower(n.title) CONTAINS $query
+			   OR toLower(n.content) CONTAINS $query
 			   OR any(tag IN tags WHERE toLower(tag) CONTAINS $query)
+			   OR any(k IN keys(n) WHERE k STARTS WITH $prefix AND toLower(n[k]) CONTAINS $query)
 			RETURN n, tags
 		`
 	default:
 		cypher = `
-			MATCH (n:Node)
+			MATCH (n:Node {workspace: $workspace})
 			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
 			RETURN n, collect(distinct t.name) as tags
 		`
 	}
 
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
-	defer session.Close(ctx)
+	res, err := t.tx.Run(ctx, cypher, params)
+	if err != nil {
+		return nil, err
+	}
+	return collectNodes(ctx, res)
+}
 
-	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		res, err := tx.Run(ctx, cypher, params)
-		if err != nil {
-			return nil, err
+// collectNodes reads nodes from records with an "n" node column and a "tags" list column.
+func collectNodes(ctx context.Context, res neo4j.ResultWithContext) ([]*domain.Node, error) {
+	var nodes []*domain.Node
+	for res.Next(ctx) {
+		record := res.Record()
+		nVal, ok := record.Get("n")
+		if !ok {
+			continue
 		}
-		var nodes []*domain.Node
-		for res.Next(ctx) {
-			record := res.Record()
-			nVal, ok := record.Get("n")
-			if !ok {
-				continue
-			}
-			nNode, ok := nVal.(neo4j.Node)
-			if !ok {
-				continue
-			}
-			props := nNode.Props
-			node := &domain.Node{
-				ID:      props["id"].(string),
-				Title:   props["title"].(string),
-				Content: props["content"].(string),
-				Type:    domain.NodeType(props["type"].(string)),
-				Tags:    []string{},
-			}
-			if tagsVal, found := record.Get("tags"); found {
-				if tagsSlice, ok := tagsVal.([]interface{}); ok {
-					for _, t := range tagsSlice {
-						if tagStr, ok := t.(string); ok {
-							node.Tags = append(node.Tags, tagStr)
-						}
+		nNode, ok := nVal.(neo4j.Node)
+		if !ok {
+			continue
+		}
+		props := nNode.Props
+		node := &domain.Node{
+			ID:      props["id"].(string),
+			Title:   props["title"].(string),
+			Content: props["content"].(string),
+			Type:    domain.NodeType(props["type"].(string)),
+			Tags:    []string{},
+
+			Properties: nodeProperties(props),
+		}
+		if tagsVal, found := record.Get("tags"); found {
+			if tagsSlice, ok := tagsVal.([]interface{}); ok {
+				for _, t := range tagsSlice {
+					if tagStr, ok := t.(string); ok {
+						node.Tags = append(node.Tags, tagStr)
 					}
 				}
 			}
-			nodes = append(nodes, node)
-		}
-		if err = res.Err(); err != nil {
-			return nil, err
 		}
-		return nodes, nil
-	})
-	if err != nil {
+		nodes = append(nodes, node)
+	}
+	if err := res.Err(); err != nil {
 		return nil, err
 	}
-	return result.([]*domain.Node), nil
+	return nodes, nil
+}
+
+// relationshipByIDUnion returns a UNION of typed lookups of the relationship r with id $id.
+func relationshipByIDUnion(types []domain.RelationType) string {
+	branches := make([]string, 0, len(types))
+	for _, t := range types {
+		branches = append(branches, "MATCH (:Node {workspace: $workspace})-[r:"+string(t)+" {id: $id}]->() RETURN r")
+	}
+	return strings.Join(branches, "\n\t\t\tUNION\n\t\t\t")
 }
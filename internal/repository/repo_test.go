@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
 )
 
 var testDriver neo4j.DriverWithContext
@@ -26,9 +27,11 @@ func TestMain(m *testing.M) {
 
 	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
 		Repository: "neo4j",
-		Tag:        "4.4",
+		// The enterprise edition can create the databases of workspaces.
+		Tag: "5.9.0-enterprise",
 		Env: []string{
 			"NEO4J_AUTH=neo4j/password",
+			"NEO4J_ACCEPT_LICENSE_AGREEMENT=yes",
 		},
 	}, func(config *docker.HostConfig) {
 		config.AutoRemove = true
@@ -321,3 +324,457 @@ func TestSearchNodesAll(t *testing.T) {
 	err = repo.DeleteNode(ctx, node2.ID)
 	assert.NoError(t, err, "DeleteNode for node2 should succeed")
 }
+
+func TestWithinTxRollsBack(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver)
+
+	var id string
+	failure := fmt.Errorf("link failed")
+	err := repo.WithinTx(ctx, func(tx usecase.Tx) error {
+		var err error
+		id, err = tx.CreateNode(ctx, &domain.Node{
+			Title:   "Rolled Back Node",
+			Content: "Should not be committed",
+			Type:    domain.Concept,
+			Tags:    []string{"rollback"},
+		})
+		if err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure, "WithinTx should return the error of fn")
+	assert.NotEqual(t, "", id, "Node id should be assigned inside the transaction")
+
+	_, err = repo.GetNodeByID(ctx, id)
+	assert.Error(t, err, "Node created in a rolled back transaction should not exist")
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver)
+
+	err := repo.Migrate(ctx)
+	assert.NoError(t, err, "First Migrate should succeed")
+	err = repo.Migrate(ctx)
+	assert.NoError(t, err, "Second Migrate should succeed")
+
+	applied, err := repo.AppliedMigrations(ctx)
+	assert.NoError(t, err, "AppliedMigrations error should be nil")
+	assert.Len(t, applied, len(Migrations), "Every migration should be recorded exactly once")
+}
+
+func TestTagNamesAreUniquePerWorkspace(t *testing.T) {
+	ctx := context.Background()
+	assert.NoError(t, NewNodeRepository(testDriver).Migrate(ctx), "Migrate should succeed")
+
+	create := `CREATE (:Tag {name: 'unique-test', workspace: 'tag-constraint-test'})`
+	_, err := neo4j.ExecuteQuery(ctx, testDriver, create, nil, neo4j.EagerResultTransformer)
+	assert.NoError(t, err, "The first tag should be created")
+	_, err = neo4j.ExecuteQuery(ctx, testDriver, create, nil, neo4j.EagerResultTransformer)
+	assert.Error(t, err, "A second tag of the same name and workspace should violate the constraint")
+}
+
+func TestMigrateKeepsDataOfWorkspaceDatabase(t *testing.T) {
+	ctx := context.Background()
+	_, err := neo4j.ExecuteQuery(ctx, testDriver, `CREATE DATABASE legacy IF NOT EXISTS WAIT`, nil,
+		neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("system"))
+	assert.NoError(t, err, "The workspace database should be created")
+	// Data written before nodes and tags were stamped with a workspace.
+	_, err = neo4j.ExecuteQuery(ctx, testDriver, `
+		CREATE (:Node {id: 'legacy-1', title: 'Before workspaces', content: '', type: 'Note', tags: ['old'],
+		               created_at: datetime(), updated_at: datetime()})-[:HAS_TAG]->(:Tag {name: 'old'})`,
+		nil, neo4j.EagerResultTransformer, neo4j.ExecuteQueryWithDatabase("legacy"))
+	assert.NoError(t, err, "The unstamped node should be created")
+
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "research", Database: "legacy"})
+	assert.NoError(t, repo.Migrate(ctx), "Migrate should succeed")
+
+	node, err := repo.GetNodeByID(ctx, "legacy-1")
+	if assert.NoError(t, err, "The node should belong to the workspace of its database") {
+		assert.Equal(t, "Before workspaces", node.Title)
+	}
+	results, err := repo.SearchNodes(ctx, "old", "Tag")
+	assert.NoError(t, err, "SearchNodes should succeed")
+	assert.Len(t, results, 1, "The tag should belong to the workspace of its database")
+}
+
+func TestWorkspacesAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver)
+	research := repo.WithWorkspace(domain.Workspace{Name: "research"})
+
+	id, err := research.CreateNode(ctx, &domain.Node{
+		Title:   "Research Node",
+		Content: "Only visible in research",
+		Type:    domain.Note,
+		Tags:    []string{"research"},
+	})
+	assert.NoError(t, err, "CreateNode in research workspace should succeed")
+
+	_, err = repo.GetNodeByID(ctx, id)
+	assert.Error(t, err, "Node should not be visible in the default workspace")
+
+	results, err := repo.SearchNodes(ctx, "research", "Tag")
+	assert.NoError(t, err, "SearchNodes in default workspace should not error")
+	assert.Len(t, results, 0, "Tags of another workspace should not match")
+
+	found, err := research.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "Node should be visible in its own workspace")
+	assert.Equal(t, "Research Node", found.Title, "Titles should match")
+
+	err = research.DeleteNode(ctx, id)
+	assert.NoError(t, err, "DeleteNode in research workspace should succeed")
+}
+
+func TestHierarchicalTags(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "tags-test"})
+
+	id, err := repo.CreateNode(ctx, &domain.Node{
+		Title:   "Goroutines",
+		Content: "Lightweight threads",
+		Type:    domain.Concept,
+		Tags:    []string{"lang/go/concurrency"},
+	})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	tags, err := repo.ListTags(ctx)
+	assert.NoError(t, err, "ListTags should succeed")
+	assert.Len(t, tags, 3, "Parent tags should be created")
+
+	nodes, err := repo.NodesByTag(ctx, "lang")
+	assert.NoError(t, err, "NodesByTag should succeed")
+	assert.Len(t, nodes, 1, "Searching a parent tag should include descendants")
+
+	err = repo.RenameTag(ctx, "lang", "languages")
+	assert.NoError(t, err, "RenameTag should succeed")
+	node, err := repo.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "GetNodeByID should succeed")
+	assert.Equal(t, []string{"languages/go/concurrency"}, node.Tags, "Renaming a parent should cascade to children")
+
+	err = repo.DeleteNode(ctx, id)
+	assert.NoError(t, err, "DeleteNode should succeed")
+	orphans, err := repo.DeleteOrphanTags(ctx)
+	assert.NoError(t, err, "DeleteOrphanTags should succeed")
+	assert.Len(t, orphans, 3, "The whole unused hierarchy should be removed")
+}
+
+func TestUserDefinedRelationType(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "types-test"})
+
+	cites := domain.RelationTypeDef{Name: "CITES", Directed: true, Description: "Quotes the target", Rules: domain.RelationRules{
+		NoSelfLoops: true,
+		TargetTypes: []domain.NodeType{domain.Reference},
+	}}
+	err := repo.SaveRelationType(ctx, cites)
+	assert.NoError(t, err, "SaveRelationType should succeed")
+	defs, err := repo.ListRelationTypes(ctx)
+	assert.NoError(t, err, "ListRelationTypes should succeed")
+	assert.Equal(t, []domain.RelationTypeDef{cites}, defs, "The rules should be stored with the type")
+
+	sourceID, err := repo.CreateNode(ctx, &domain.Node{Title: "Source", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+	targetID, err := repo.CreateNode(ctx, &domain.Node{Title: "Target", Type: domain.Reference})
+	assert.NoError(t, err, "CreateNode should succeed")
+	relIDs, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: sourceID, TargetIDs: []string{targetID}, Type: "CITES"})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	assert.Len(t, relIDs, 1, "One relationship should be created")
+
+	count, err := repo.CountRelationshipsOfType(ctx, "CITES")
+	assert.NoError(t, err, "CountRelationshipsOfType should succeed")
+	assert.Equal(t, 1, count)
+
+	err = repo.DeleteRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "DeleteRelationship should find user-defined types")
+	count, err = repo.CountRelationshipsOfType(ctx, "CITES")
+	assert.NoError(t, err, "CountRelationshipsOfType should succeed")
+	assert.Equal(t, 0, count, "The relationship should be deleted")
+}
+
+func TestNodeProperties(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "properties-test"})
+
+	id, err := repo.CreateNode(ctx, &domain.Node{
+		Title:      "Paper",
+		Type:       domain.Reference,
+		Properties: map[string]string{"url": "https://example.com", "author": "Turing"},
+	})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	node, err := repo.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "GetNodeByID should succeed")
+	assert.Equal(t, map[string]string{"url": "https://example.com", "author": "Turing"}, node.Properties)
+
+	node.Properties = map[string]string{"author": "Alan Turing"}
+	err = repo.UpdateNode(ctx, node)
+	assert.NoError(t, err, "UpdateNode should succeed")
+	node, err = repo.GetNodeByID(ctx, id)
+	assert.NoError(t, err, "GetNodeByID should succeed")
+	assert.Equal(t, map[string]string{"author": "Alan Turing"}, node.Properties, "Dropped properties should be removed")
+
+	found, err := repo.SearchNodes(ctx, "author:alan", "Property")
+	assert.NoError(t, err, "SearchNodes should succeed")
+	assert.Len(t, found, 1, "Nodes should be found by property")
+	assert.Equal(t, "Alan Turing", found[0].Properties["author"])
+}
+
+func TestGetAndListRelationships(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "relationships-test"})
+
+	partID, err := repo.CreateNode(ctx, &domain.Node{Title: "Engine", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	wholeID, err := repo.CreateNode(ctx, &domain.Node{Title: "Car", Type: domain.Concept, Tags: []string{"vehicle"}})
+	assert.NoError(t, err, "CreateNode should succeed")
+	relIDs, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: partID, TargetIDs: []string{wholeID}, Type: domain.IsPartOf})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	rel, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+	assert.Equal(t, partID, rel.SourceID)
+	assert.Equal(t, []string{wholeID}, rel.TargetIDs)
+	assert.Equal(t, domain.IsPartOf, rel.Type)
+
+	rels, err := repo.ListRelationships(ctx, wholeID)
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 1, "Tag links should not be listed")
+	rels, err = repo.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 1, "All relationships of the workspace should be listed")
+}
+
+func TestRelationshipAttributes(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "relationship-attributes-test"})
+
+	sourceID, err := repo.CreateNode(ctx, &domain.Node{Title: "Go", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	targetID, err := repo.CreateNode(ctx, &domain.Node{Title: "Generics", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	validFrom := time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)
+	relIDs, err := repo.CreateRelationship(ctx, &domain.Relationship{
+		SourceID: sourceID, TargetIDs: []string{targetID}, Type: domain.RelatedTo,
+		Weight: 2.5, Confidence: 0.75, ValidFrom: &validFrom,
+	})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	rel, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+	assert.Equal(t, 2.5, rel.Weight)
+	assert.Equal(t, 0.75, rel.Confidence)
+	assert.Equal(t, &validFrom, rel.ValidFrom, "Dates should be stored as Neo4j dates")
+	assert.Nil(t, rel.ValidTo, "Unset bounds should stay open")
+}
+
+func TestUpdateRelationship(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "update-relationship-test"})
+
+	sourceID, err := repo.CreateNode(ctx, &domain.Node{Title: "Go", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	targetID, err := repo.CreateNode(ctx, &domain.Node{Title: "Generics", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	relIDs, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: sourceID, TargetIDs: []string{targetID}, Type: domain.RelatedTo, Weight: 2})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	created, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+
+	err = repo.UpdateRelationship(ctx, &domain.Relationship{ID: relIDs[0], Type: domain.RelatedTo, Description: "fixed"})
+	assert.NoError(t, err, "UpdateRelationship should succeed")
+	rel, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+	assert.Equal(t, "fixed", rel.Description)
+	assert.Zero(t, rel.Weight, "Cleared attributes should be removed")
+
+	err = repo.UpdateRelationship(ctx, &domain.Relationship{ID: relIDs[0], Type: domain.DependsOn, Description: "fixed"})
+	assert.NoError(t, err, "Changing the type should succeed")
+	rel, err = repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "The recreated relationship should keep its id")
+	assert.Equal(t, domain.DependsOn, rel.Type)
+	assert.Equal(t, created.CreatedAt.Unix(), rel.CreatedAt.Unix(), "The creation time should be kept")
+	assert.Equal(t, sourceID, rel.SourceID)
+
+	rels, err := repo.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 1, "The original relationship should be deleted")
+}
+
+func TestFindPaths(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "find-paths-test"})
+
+	ids := make([]string, 4)
+	for i := range ids {
+		id, err := repo.CreateNode(ctx, &domain.Node{Title: fmt.Sprintf("Node %d", i), Type: domain.Concept, Tags: []string{"shared"}})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[i] = id
+	}
+	for _, link := range [][2]int{{0, 1}, {2, 1}, {0, 3}, {3, 2}} {
+		_, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[link[0]], TargetIDs: []string{ids[link[1]]}, Type: domain.RelatedTo})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+
+	paths, err := repo.FindPaths(ctx, ids[0], ids[2], domain.PathOptions{All: true})
+	assert.NoError(t, err, "FindPaths should succeed")
+	assert.Len(t, paths, 2, "Both two-hop paths should be found and tags should not connect nodes")
+	for _, p := range paths {
+		assert.Len(t, p.NodeIDs, 3)
+		assert.Len(t, p.RelationshipIDs, 2)
+	}
+
+	paths, err = repo.FindPaths(ctx, ids[0], ids[2], domain.PathOptions{RelationTypes: []domain.RelationType{domain.DependsOn}})
+	assert.NoError(t, err, "FindPaths should succeed")
+	assert.Empty(t, paths, "The type filter should be applied")
+}
+
+func TestGetNeighborhood(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "neighborhood-test"})
+
+	ids := make([]string, 4)
+	for i := range ids {
+		id, err := repo.CreateNode(ctx, &domain.Node{Title: fmt.Sprintf("Node %d", i), Type: domain.Concept, Tags: []string{"shared"}})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[i] = id
+	}
+	for _, link := range [][2]int{{0, 1}, {1, 2}, {3, 0}} {
+		_, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[link[0]], TargetIDs: []string{ids[link[1]]}, Type: domain.References})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+
+	n, err := repo.GetNeighborhood(ctx, ids[0], 1, domain.NeighborhoodFilter{})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Len(t, n.Nodes, 3, "Direct neighbours in both directions should be returned and tags should not connect nodes")
+	assert.Equal(t, ids[0], n.Nodes[0].ID, "The start node should come first")
+	assert.Len(t, n.Relationships, 2)
+
+	n, err = repo.GetNeighborhood(ctx, ids[0], 2, domain.NeighborhoodFilter{Direction: domain.DirectionOutgoing})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	if assert.Len(t, n.Nodes, 3, "Incoming links should not be followed") {
+		assert.Equal(t, []string{ids[0], ids[1], ids[2]}, []string{n.Nodes[0].ID, n.Nodes[1].ID, n.Nodes[2].ID}, "Nodes should be ordered by distance")
+	}
+
+	n, err = repo.GetNeighborhood(ctx, ids[0], 2, domain.NeighborhoodFilter{RelationTypes: []domain.RelationType{domain.DependsOn}})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Len(t, n.Nodes, 1, "The type filter should be applied")
+	assert.Empty(t, n.Relationships)
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "restore-test"})
+
+	nodeID, err := repo.CreateNode(ctx, &domain.Node{Title: "Kept", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	otherID, err := repo.CreateNode(ctx, &domain.Node{Title: "Deleted", Type: domain.Note, Tags: []string{"old"}})
+	assert.NoError(t, err, "CreateNode should succeed")
+	relIDs, err := repo.CreateRelationship(ctx, &domain.Relationship{SourceID: otherID, TargetIDs: []string{nodeID}, Type: domain.DependsOn, Weight: 2})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	deleted, err := repo.GetNodeByID(ctx, otherID)
+	assert.NoError(t, err, "GetNodeByID should succeed")
+	rel, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+	assert.NoError(t, repo.DeleteNode(ctx, otherID), "DeleteNode should succeed")
+
+	assert.NoError(t, repo.RestoreNode(ctx, deleted), "RestoreNode should succeed")
+	assert.Error(t, repo.RestoreNode(ctx, deleted), "Existing nodes should not be restored twice")
+	restored, err := repo.GetNodeByID(ctx, otherID)
+	assert.NoError(t, err, "The node should keep its id")
+	assert.Equal(t, deleted.Title, restored.Title)
+	assert.Equal(t, []string{"old"}, restored.Tags)
+	assert.True(t, deleted.CreatedAt.Equal(restored.CreatedAt), "The creation time should be kept")
+
+	assert.NoError(t, repo.RestoreRelationship(ctx, rel), "RestoreRelationship should succeed")
+	restoredRel, err := repo.GetRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "The relationship should keep its id")
+	assert.Equal(t, 2.0, restoredRel.Weight)
+	assert.Equal(t, otherID, restoredRel.SourceID)
+}
+
+func TestViews(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "view-test"})
+
+	view := domain.View{
+		Name:      "reading",
+		Query:     "go",
+		Criteria:  "Tag",
+		Layout:    "Circular",
+		Positions: map[string]domain.Position{"a": {X: 10, Y: 20.5}},
+		Zoom:      1.5,
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	assert.NoError(t, repo.SaveView(ctx, view), "SaveView should succeed")
+	view.Positions["b"] = domain.Position{X: 30, Y: 40}
+	assert.NoError(t, repo.SaveView(ctx, view), "Saving again should replace the view")
+
+	found, err := repo.GetView(ctx, "reading")
+	assert.NoError(t, err, "GetView should succeed")
+	if assert.NotNil(t, found, "The view should be found") {
+		assert.Equal(t, view.Positions, found.Positions, "Positions should round-trip")
+		assert.Equal(t, 1.5, found.Zoom)
+		assert.Equal(t, "Tag", found.Criteria)
+		assert.True(t, view.UpdatedAt.Equal(found.UpdatedAt))
+	}
+	views, err := repo.ListViews(ctx)
+	assert.NoError(t, err, "ListViews should succeed")
+	assert.Len(t, views, 1, "Views should be stored once per name")
+
+	assert.NoError(t, repo.DeleteView(ctx, "reading"), "DeleteView should succeed")
+	found, err = repo.GetView(ctx, "reading")
+	assert.NoError(t, err, "GetView of a missing view should not fail")
+	assert.Nil(t, found)
+}
+
+func TestNodeRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewNodeRepository(testDriver).WithWorkspace(domain.Workspace{Name: "revision-test"})
+
+	node := &domain.Node{Title: "First", Content: "one", Type: domain.Note, Tags: []string{"go"}}
+	id, err := repo.CreateNode(ctx, node)
+	assert.NoError(t, err, "CreateNode should succeed")
+	node.ID = id
+	node.Title = "Second"
+	assert.NoError(t, repo.UpdateNode(ctx, node), "UpdateNode should succeed")
+
+	revisions, err := repo.ListNodeRevisions(ctx, id)
+	assert.NoError(t, err, "ListNodeRevisions should succeed")
+	if assert.Len(t, revisions, 1, "The update should keep the former version") {
+		assert.Equal(t, "First", revisions[0].Title)
+		assert.Equal(t, "one", revisions[0].Content)
+		assert.Equal(t, []string{"go"}, revisions[0].Tags)
+		assert.Equal(t, id, revisions[0].NodeID)
+		assert.False(t, revisions[0].SavedAt.IsZero(), "The revision should keep its save time")
+	}
+
+	other := &domain.Node{Title: "Other", Type: domain.Note}
+	otherID, err := repo.CreateNode(ctx, other)
+	assert.NoError(t, err, "CreateNode should succeed")
+	assert.NoError(t, repo.RestoreNodeRevisions(ctx, otherID, revisions), "RestoreNodeRevisions should succeed")
+	restored, err := repo.ListNodeRevisions(ctx, otherID)
+	assert.NoError(t, err, "ListNodeRevisions should succeed")
+	if assert.Len(t, restored, 1, "The revision should be restored") {
+		assert.Equal(t, "First", restored[0].Title)
+		assert.Equal(t, otherID, restored[0].NodeID)
+		assert.Equal(t, revisions[0].SavedAt.Unix(), restored[0].SavedAt.Unix())
+	}
+
+	for i := 0; i < domain.MaxNodeRevisions; i++ {
+		assert.NoError(t, repo.UpdateNode(ctx, node), "UpdateNode should succeed")
+	}
+	revisions, err = repo.ListNodeRevisions(ctx, id)
+	assert.NoError(t, err, "ListNodeRevisions should succeed")
+	assert.Len(t, revisions, domain.MaxNodeRevisions, "Only the newest revisions should be kept")
+	assert.NoError(t, repo.DeleteNode(ctx, otherID), "DeleteNode should succeed")
+
+	assert.NoError(t, repo.DeleteNode(ctx, id), "DeleteNode should succeed")
+	revisions, err = repo.ListNodeRevisions(ctx, id)
+	assert.NoError(t, err, "ListNodeRevisions should succeed")
+	assert.Empty(t, revisions, "Deleting a node should delete its revisions")
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func (r *NodeRepository) RestoreNode(ctx context.Context, node *domain.Node) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.RestoreNode(ctx, node)
+	})
+	return err
+}
+
+func (r *NodeRepository) RestoreRelationship(ctx context.Context, rel *domain.Relationship) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.RestoreRelationship(ctx, rel)
+	})
+	return err
+}
+
+// RestoreNode creates the node like CreateNode, but with its own id and timestamps.
+func (t *txRepository) RestoreNode(ctx context.Context, node *domain.Node) error {
+	existing, err := t.count(ctx, `MATCH (n:Node {id: $id, workspace: $workspace}) RETURN count(n) AS count`,
+		map[string]interface{}{"id": node.ID, "workspace": t.workspace})
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		return fmt.Errorf("node %s already exists", node.ID)
+	}
+
+	query := `
+		CREATE (n:Node {
+			id: $id,
+			workspace: $workspace,
+			title: $title,
+			content: $content,
+			type: $type,
+			created_at: datetime($created_at),
+			updated_at: datetime($updated_at),
+			tags: $tags
+		})
+		SET n:` + string(node.Type) + `, n += $properties
+			FOREACH (tag IN $tags | MERGE (t:Tag {name: tag, workspace: $workspace}) MERGE (n)-[:HAS_TAG]->(t))
+			` + linkTagParents + `
+			RETURN n.id as id
+	`
+	params := map[string]interface{}{
+		"id":         node.ID,
+		"workspace":  t.workspace,
+		"title":      node.Title,
+		"content":    node.Content,
+		"type":       string(node.Type),
+		"created_at": node.CreatedAt.Format(time.RFC3339),
+		"updated_at": node.UpdatedAt.Format(time.RFC3339),
+		"tags":       node.Tags,
+		"tag_links":  tagLinks(node.Tags),
+		"properties": propertyParams(node.Properties, nil),
+	}
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+	_, err = result.Single(ctx)
+	return err
+}
+
+// RestoreRelationship creates the relationship like CreateRelationship, but with its own
+// id and creation time. The relationship type is concatenated into the query, so it must
+// have been validated by the caller.
+func (t *txRepository) RestoreRelationship(ctx context.Context, rel *domain.Relationship) error {
+	query := `
+		MATCH (source:Node {id: $source_id, workspace: $workspace})
+		MATCH (target:Node {id: $target_id, workspace: $workspace})
+		CREATE (source)-[r:` + string(rel.Type) + ` {
+			id: $id,
+			description: $description,
+			created_at: datetime($created_at),
+			weight: $weight,
+			confidence: $confidence,
+			valid_from: date($valid_from),
+			valid_to: date($valid_to)
+		}]->(target)
+		RETURN r.id AS id
+	`
+	params := map[string]interface{}{
+		"id":          rel.ID,
+		"workspace":   t.workspace,
+		"source_id":   rel.SourceID,
+		"target_id":   rel.TargetIDs[0],
+		"description": rel.Description,
+		"created_at":  rel.CreatedAt.Format(time.RFC3339),
+		"weight":      optionalFloat(rel.Weight),
+		"confidence":  optionalFloat(rel.Confidence),
+		"valid_from":  dateParam(rel.ValidFrom),
+		"valid_to":    dateParam(rel.ValidTo),
+	}
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+	if _, err := result.Single(ctx); err != nil {
+		return fmt.Errorf("relationship %s: endpoints not found: %w", rel.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func (r *NodeRepository) ListNodeRevisions(ctx context.Context, nodeID string) ([]domain.NodeRevision, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.ListNodeRevisions(ctx, nodeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.NodeRevision), nil
+}
+
+func (r *NodeRepository) RestoreNodeRevisions(ctx context.Context, nodeID string, revisions []domain.NodeRevision) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.RestoreNodeRevisions(ctx, nodeID, revisions)
+	})
+	return err
+}
+
+// ListNodeRevisions returns the revisions UpdateNode kept for the node, newest first.
+// A revision holds a copy of the node properties, so updated_at is the time the version
+// was saved.
+func (t *txRepository) ListNodeRevisions(ctx context.Context, nodeID string) ([]domain.NodeRevision, error) {
+	query := `
+		MATCH (:Node {id: $id, workspace: $workspace})-[:HAS_REVISION]->(rev:NodeRevision)
+		RETURN properties(rev) AS props
+		ORDER BY rev.updated_at DESC
+	`
+	params := map[string]interface{}{
+		"id":        nodeID,
+		"workspace": t.workspace,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []domain.NodeRevision
+	for res.Next(ctx) {
+		props, _ := res.Record().Get("props")
+		revisions = append(revisions, revisionFromProperties(props.(map[string]interface{})))
+	}
+	return revisions, res.Err()
+}
+
+// RestoreNodeRevisions links a NodeRevision holding each of revisions to the node, with
+// the properties UpdateNode would have copied.
+func (t *txRepository) RestoreNodeRevisions(ctx context.Context, nodeID string, revisions []domain.NodeRevision) error {
+	query := `
+		MATCH (n:Node {id: $id, workspace: $workspace})
+		UNWIND $revisions AS saved
+		CREATE (n)-[:HAS_REVISION]->(rev:NodeRevision)
+		SET rev = saved.fields,
+		    rev.updated_at = datetime(saved.updated_at),
+		    rev += saved.properties
+	`
+	saved := make([]map[string]interface{}, len(revisions))
+	for i, rev := range revisions {
+		saved[i] = map[string]interface{}{
+			"fields": map[string]interface{}{
+				"node_id":   nodeID,
+				"workspace": t.workspace,
+				"title":     rev.Title,
+				"content":   rev.Content,
+				"type":      string(rev.Type),
+				"tags":      rev.Tags,
+			},
+			"updated_at": rev.SavedAt.Format(time.RFC3339),
+			"properties": propertyParams(rev.Properties, nil),
+		}
+	}
+	params := map[string]interface{}{
+		"id":        nodeID,
+		"workspace": t.workspace,
+		"revisions": saved,
+	}
+	result, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+	_, err = result.Consume(ctx)
+	return err
+}
+
+// revisionFromProperties builds a revision from the properties of a NodeRevision node.
+func revisionFromProperties(props map[string]interface{}) domain.NodeRevision {
+	rev := domain.NodeRevision{Properties: nodeProperties(props)}
+	rev.NodeID, _ = props["node_id"].(string)
+	rev.Title, _ = props["title"].(string)
+	rev.Content, _ = props["content"].(string)
+	nodeType, _ := props["type"].(string)
+	rev.Type = domain.NodeType(nodeType)
+	rev.SavedAt, _ = props["updated_at"].(time.Time)
+	rev.Tags = stringList(props["tags"])
+	return rev
+}
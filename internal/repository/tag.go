@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// linkTagParents creates the ancestors of hierarchical tags and links every level
+// to its parent with CHILD_OF. It expects a $tag_links parameter built by tagLinks.
+const linkTagParents = `
+	FOREACH (link IN $tag_links |
+		MERGE (child:Tag {name: link[0], workspace: $workspace})
+		MERGE (parent:Tag {name: link[1], workspace: $workspace})
+		MERGE (child)-[:CHILD_OF]->(parent)
+	)`
+
+// tagLinks returns the [child, parent] pairs of every level of the given tags.
+func tagLinks(tags []string) [][]string {
+	links := [][]string{}
+	for _, tag := range tags {
+		for child, parent := tag, domain.ParentTag(tag); parent != ""; child, parent = parent, domain.ParentTag(parent) {
+			links = append(links, []string{child, parent})
+		}
+	}
+	return links
+}
+
+// ListTags returns every tag of the workspace with the number of nodes using it directly.
+func (r *NodeRepository) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (t:Tag {workspace: $workspace})
+			OPTIONAL MATCH (n:Node {workspace: $workspace})-[:HAS_TAG]->(t)
+			RETURN t.name AS name, count(n) AS count
+			ORDER BY name
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		var tags []domain.Tag
+		for res.Next(ctx) {
+			record := res.Record()
+			name, _ := record.Get("name")
+			count, _ := record.Get("count")
+			tags = append(tags, domain.Tag{
+				Name:   name.(string),
+				Parent: domain.ParentTag(name.(string)),
+				Count:  int(count.(int64)),
+			})
+		}
+		return tags, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.Tag), nil
+}
+
+// RenameTag renames the tag and its descendants, both the tag nodes and the copy of the
+// names kept in the tags property of nodes, and links the renamed tag to its new parent.
+func (r *NodeRepository) RenameTag(ctx context.Context, oldName, newName string) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"old":       oldName,
+			"new":       newName,
+			"prefix":    oldName + domain.TagSeparator,
+			"workspace": tx.workspace,
+			"tag_links": tagLinks([]string{newName}),
+		}
+		return nil, tx.runAll(ctx, params,
+			`MATCH (t:Tag {workspace: $workspace})
+			 WHERE t.name = $old OR t.name STARTS WITH $prefix
+			 SET t.name = $new + substring(t.name, size($old))`,
+			`MATCH (n:Node {workspace: $workspace})
+			 WHERE any(tag IN coalesce(n.tags, []) WHERE tag = $old OR tag STARTS WITH $prefix)
+			 SET n.tags = [tag IN n.tags |
+				CASE WHEN tag = $old OR tag STARTS WITH $prefix
+					THEN $new + substring(tag, size($old))
+					ELSE tag END]`,
+			`MATCH (:Tag {name: $new, workspace: $workspace})-[r:CHILD_OF]->()
+			 DELETE r`,
+			linkTagParents,
+		)
+	})
+	return err
+}
+
+// MergeTags re-links the nodes of every source tag to target and deletes the source tags.
+func (r *NodeRepository) MergeTags(ctx context.Context, sources []string, target string) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"sources":   sources,
+			"target":    target,
+			"workspace": tx.workspace,
+			"tag_links": tagLinks([]string{target}),
+		}
+		return nil, tx.runAll(ctx, params,
+			`MERGE (target:Tag {name: $target, workspace: $workspace})
+			 WITH target
+			 MATCH (source:Tag {workspace: $workspace})
+			 WHERE source.name IN $sources
+			 OPTIONAL MATCH (n:Node)-[:HAS_TAG]->(source)
+			 FOREACH (_ IN CASE WHEN n IS NULL THEN [] ELSE [1] END |
+				MERGE (n)-[:HAS_TAG]->(target)
+				SET n.tags = [tag IN coalesce(n.tags, []) WHERE NOT tag IN $sources AND tag <> $target] + $target
+			 )
+			 WITH DISTINCT source
+			 DETACH DELETE source`,
+			linkTagParents,
+		)
+	})
+	return err
+}
+
+// DeleteOrphanTags removes the tags of the workspace that neither they nor any of their
+// descendants are attached to a node.
+func (r *NodeRepository) DeleteOrphanTags(ctx context.Context) ([]string, error) {
+	result, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (t:Tag {workspace: $workspace})
+			WHERE NOT (t)<-[:CHILD_OF*0..]-(:Tag)<-[:HAS_TAG]-(:Node)
+			WITH t, t.name AS name
+			DETACH DELETE t
+			RETURN name
+			ORDER BY name
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for res.Next(ctx) {
+			name, _ := res.Record().Get("name")
+			names = append(names, name.(string))
+		}
+		return names, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
+
+// NodesByTag returns the nodes tagged with name or with one of its descendants.
+func (r *NodeRepository) NodesByTag(ctx context.Context, name string) ([]*domain.Node, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (:Tag {name: $name, workspace: $workspace})<-[:CHILD_OF*0..]-(:Tag)<-[:HAS_TAG]-(n:Node {workspace: $workspace})
+			WITH DISTINCT n
+			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+			RETURN n, collect(distinct t.name) as tags
+		`
+		params := map[string]interface{}{
+			"name":      name,
+			"workspace": tx.workspace,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return collectNodes(ctx, res)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*domain.Node), nil
+}
+
+// runAll runs the statements in order with the same parameters.
+func (t *txRepository) runAll(ctx context.Context, params map[string]interface{}, statements ...string) error {
+	for _, statement := range statements {
+		res, err := t.tx.Run(ctx, statement, params)
+		if err != nil {
+			return err
+		}
+		if _, err = res.Consume(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// ListNodeTypes returns the node type definitions stored in the workspace.
+// The property schema is stored as JSON since Neo4j properties cannot hold nested values.
+func (r *NodeRepository) ListNodeTypes(ctx context.Context) ([]domain.NodeTypeDef, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (d:NodeTypeDef {workspace: $workspace})
+			RETURN d.name AS name, d.color AS color, d.icon AS icon, d.description AS description,
+			       d.properties AS properties
+			ORDER BY name
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		var defs []domain.NodeTypeDef
+		for res.Next(ctx) {
+			record := res.Record()
+			name, _ := record.Get("name")
+			color, _ := record.Get("color")
+			icon, _ := record.Get("icon")
+			description, _ := record.Get("description")
+			properties, _ := record.Get("properties")
+			def := domain.NodeTypeDef{
+				Name:        domain.NodeType(name.(string)),
+				Color:       stringOrEmpty(color),
+				Icon:        stringOrEmpty(icon),
+				Description: stringOrEmpty(description),
+			}
+			if encoded := stringOrEmpty(properties); encoded != "" {
+				if err := json.Unmarshal([]byte(encoded), &def.Properties); err != nil {
+					return nil, err
+				}
+			}
+			defs = append(defs, def)
+		}
+		return defs, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.NodeTypeDef), nil
+}
+
+func (r *NodeRepository) SaveNodeType(ctx context.Context, def domain.NodeTypeDef) error {
+	properties, err := json.Marshal(def.Properties)
+	if err != nil {
+		return err
+	}
+	_, err = r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"workspace":   tx.workspace,
+			"name":        string(def.Name),
+			"color":       def.Color,
+			"icon":        def.Icon,
+			"description": def.Description,
+			"properties":  string(properties),
+		}
+		return nil, tx.runAll(ctx, params,
+			`MERGE (d:NodeTypeDef {name: $name, workspace: $workspace})
+			 SET d.color = $color,
+			     d.icon = $icon,
+			     d.description = $description,
+			     d.properties = $properties`,
+		)
+	})
+	return err
+}
+
+func (r *NodeRepository) DeleteNodeType(ctx context.Context, name domain.NodeType) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+			"name":      string(name),
+		}
+		return nil, tx.runAll(ctx, params,
+			`MATCH (d:NodeTypeDef {name: $name, workspace: $workspace})
+			 DELETE d`,
+		)
+	})
+	return err
+}
+
+func (r *NodeRepository) CountNodesOfType(ctx context.Context, name domain.NodeType) (int, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (n:Node {workspace: $workspace, type: $type})
+			RETURN count(n) AS count
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+			"type":      string(name),
+		}
+		return tx.count(ctx, query, params)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+// ListRelationTypes returns the relationship type definitions stored in the workspace.
+func (r *NodeRepository) ListRelationTypes(ctx context.Context) ([]domain.RelationTypeDef, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		return tx.listRelationTypes(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.RelationTypeDef), nil
+}
+
+// SaveRelationType stores the definition, with the rules as JSON, and indexes the id of the
+// new relationship type.
+func (r *NodeRepository) SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error {
+	return r.WithinTypeTx(ctx, func(tx usecase.TypeTx) error {
+		return tx.SaveRelationType(ctx, def)
+	})
+}
+
+func (r *NodeRepository) DeleteRelationType(ctx context.Context, name domain.RelationType) error {
+	return r.WithinTypeTx(ctx, func(tx usecase.TypeTx) error {
+		return tx.DeleteRelationType(ctx, name)
+	})
+}
+
+// WithinTypeTx runs fn in a single write transaction and then, in a separate transaction
+// because Neo4j does not mix schema and data changes, indexes the ids of the relationship
+// types fn saved. The driver retries fn on transient errors, so fn may be called more
+// than once.
+func (r *NodeRepository) WithinTypeTx(ctx context.Context, fn func(tx usecase.TypeTx) error) error {
+	var saved []domain.RelationType
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		types := &typeTx{tx: tx}
+		if err := fn(types); err != nil {
+			return nil, err
+		}
+		saved = types.saved
+		return nil, nil
+	})
+	if err != nil || len(saved) == 0 {
+		return err
+	}
+
+	_, err = r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		return nil, tx.runAll(ctx, nil, relationshipIDIndexes(saved)...)
+	})
+	return err
+}
+
+// typeTx is the usecase.TypeTx of WithinTypeTx. It records the saved relationship types,
+// whose ids are indexed after the commit.
+type typeTx struct {
+	tx    *txRepository
+	saved []domain.RelationType
+}
+
+func (t *typeTx) SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error {
+	rules, err := json.Marshal(def.Rules)
+	if err != nil {
+		return err
+	}
+	params := map[string]interface{}{
+		"workspace":   t.tx.workspace,
+		"name":        string(def.Name),
+		"inverse":     string(def.Inverse),
+		"directed":    def.Directed,
+		"description": def.Description,
+		"rules":       string(rules),
+	}
+	err = t.tx.runAll(ctx, params,
+		`MERGE (d:RelationTypeDef {name: $name, workspace: $workspace})
+		 SET d.inverse = $inverse,
+		     d.directed = $directed,
+		     d.description = $description,
+		     d.rules = $rules`,
+	)
+	if err != nil {
+		return err
+	}
+	t.saved = append(t.saved, def.Name)
+	return nil
+}
+
+func (t *typeTx) DeleteRelationType(ctx context.Context, name domain.RelationType) error {
+	params := map[string]interface{}{
+		"workspace": t.tx.workspace,
+		"name":      string(name),
+	}
+	return t.tx.runAll(ctx, params,
+		`MATCH (d:RelationTypeDef {name: $name, workspace: $workspace})
+		 DELETE d`,
+	)
+}
+
+// CountRelationshipsOfType returns the number of relationships of the given type.
+// The caller must have validated name, it is used as relationship type in the query.
+func (r *NodeRepository) CountRelationshipsOfType(ctx context.Context, name domain.RelationType) (int, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (:Node {workspace: $workspace})-[r:` + string(name) + `]->()
+			RETURN count(r) AS count
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+		}
+		return tx.count(ctx, query, params)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int), nil
+}
+
+func (t *txRepository) listRelationTypes(ctx context.Context) ([]domain.RelationTypeDef, error) {
+	query := `
+		MATCH (d:RelationTypeDef {workspace: $workspace})
+		RETURN d.name AS name, d.inverse AS inverse, d.directed AS directed, d.description AS description,
+		       d.rules AS rules
+		ORDER BY name
+	`
+	params := map[string]interface{}{
+		"workspace": t.workspace,
+	}
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	var defs []domain.RelationTypeDef
+	for res.Next(ctx) {
+		record := res.Record()
+		name, _ := record.Get("name")
+		inverse, _ := record.Get("inverse")
+		directed, _ := record.Get("directed")
+		description, _ := record.Get("description")
+		rules, _ := record.Get("rules")
+		isDirected, _ := directed.(bool)
+		def := domain.RelationTypeDef{
+			Name:        domain.RelationType(name.(string)),
+			Inverse:     domain.RelationType(stringOrEmpty(inverse)),
+			Directed:    isDirected,
+			Description: stringOrEmpty(description),
+		}
+		if encoded := stringOrEmpty(rules); encoded != "" {
+			if err := json.Unmarshal([]byte(encoded), &def.Rules); err != nil {
+				return nil, err
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, res.Err()
+}
+
+// relationTypes returns the names of the built-in and user-defined relationship types.
+func (t *txRepository) relationTypes(ctx context.Context) ([]domain.RelationType, error) {
+	defs, err := t.listRelationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registry := domain.NewTypeRegistry(nil, defs)
+	types := make([]domain.RelationType, 0, len(registry.RelationTypes))
+	for _, def := range registry.RelationTypes {
+		types = append(types, def.Name)
+	}
+	return types, nil
+}
+
+// count runs a query returning a single "count" column.
+func (t *txRepository) count(ctx context.Context, query string, params map[string]interface{}) (int, error) {
+	res, err := t.tx.Run(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	record, err := res.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := record.Get("count")
+	return int(count.(int64)), nil
+}
+
+func stringOrEmpty(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
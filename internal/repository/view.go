@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// viewColumns are the columns read by viewFromRecord.
+const viewColumns = `
+	v.name AS name, v.query AS query, v.criteria AS criteria, v.layout AS layout,
+	v.zoom AS zoom, v.positions AS positions, v.updated_at AS updated_at
+`
+
+// ListViews returns the views stored in the workspace. Positions are stored as JSON
+// since Neo4j properties cannot hold maps.
+func (r *NodeRepository) ListViews(ctx context.Context) ([]domain.View, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (v:View {workspace: $workspace})
+			RETURN ` + viewColumns + `
+			ORDER BY name
+		`
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		var views []domain.View
+		for res.Next(ctx) {
+			view, err := viewFromRecord(res.Record())
+			if err != nil {
+				return nil, err
+			}
+			views = append(views, *view)
+		}
+		return views, res.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]domain.View), nil
+}
+
+func (r *NodeRepository) GetView(ctx context.Context, name string) (*domain.View, error) {
+	result, err := r.read(ctx, func(tx *txRepository) (interface{}, error) {
+		query := `
+			MATCH (v:View {name: $name, workspace: $workspace})
+			RETURN ` + viewColumns
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+			"name":      name,
+		}
+		res, err := tx.tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		if !res.Next(ctx) {
+			return (*domain.View)(nil), res.Err()
+		}
+		return viewFromRecord(res.Record())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*domain.View), nil
+}
+
+func (r *NodeRepository) SaveView(ctx context.Context, view domain.View) error {
+	positions, err := json.Marshal(view.Positions)
+	if err != nil {
+		return err
+	}
+	_, err = r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"workspace":  tx.workspace,
+			"name":       view.Name,
+			"query":      view.Query,
+			"criteria":   view.Criteria,
+			"layout":     view.Layout,
+			"zoom":       view.Zoom,
+			"positions":  string(positions),
+			"updated_at": view.UpdatedAt.Format(time.RFC3339),
+		}
+		return nil, tx.runAll(ctx, params,
+			`MERGE (v:View {name: $name, workspace: $workspace})
+			 SET v.query = $query,
+			     v.criteria = $criteria,
+			     v.layout = $layout,
+			     v.zoom = $zoom,
+			     v.positions = $positions,
+			     v.updated_at = datetime($updated_at)`,
+		)
+	})
+	return err
+}
+
+func (r *NodeRepository) DeleteView(ctx context.Context, name string) error {
+	_, err := r.write(ctx, func(tx *txRepository) (interface{}, error) {
+		params := map[string]interface{}{
+			"workspace": tx.workspace,
+			"name":      name,
+		}
+		return nil, tx.runAll(ctx, params,
+			`MATCH (v:View {name: $name, workspace: $workspace})
+			 DELETE v`,
+		)
+	})
+	return err
+}
+
+func viewFromRecord(record *neo4j.Record) (*domain.View, error) {
+	name, _ := record.Get("name")
+	query, _ := record.Get("query")
+	criteria, _ := record.Get("criteria")
+	layout, _ := record.Get("layout")
+	zoom, _ := record.Get("zoom")
+	positions, _ := record.Get("positions")
+	updatedAt, _ := record.Get("updated_at")
+	view := &domain.View{
+		Name:     name.(string),
+		Query:    stringOrEmpty(query),
+		Criteria: stringOrEmpty(criteria),
+		Layout:   stringOrEmpty(layout),
+	}
+	view.Zoom, _ = zoom.(float64)
+	view.UpdatedAt, _ = updatedAt.(time.Time)
+	if encoded := stringOrEmpty(positions); encoded != "" {
+		if err := json.Unmarshal([]byte(encoded), &view.Positions); err != nil {
+			return nil, err
+		}
+	}
+	return view, nil
+}
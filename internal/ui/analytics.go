@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// Colour modes of the analytics dialog. colorByType restores the type colours.
+const (
+	colorByType      = "Type"
+	colorByCommunity = "Community"
+	colorByComponent = "Component"
+	colorByPageRank  = "PageRank"
+	colorByDeadEnds  = "Orphans and dead ends"
+)
+
+// analyticsTopNodes is the number of most central nodes named in the summary.
+const analyticsTopNodes = 5
+
+var colorModes = []string{colorByType, colorByCommunity, colorByComponent, colorByPageRank, colorByDeadEnds}
+
+// groupPalette colours communities and components; groups beyond it share the last colour.
+var groupPalette = []color.Color{
+	color.RGBA{R: 31, G: 119, B: 180, A: 255},
+	color.RGBA{R: 255, G: 127, B: 14, A: 255},
+	color.RGBA{R: 44, G: 160, B: 44, A: 255},
+	color.RGBA{R: 214, G: 39, B: 40, A: 255},
+	color.RGBA{R: 148, G: 103, B: 189, A: 255},
+	color.RGBA{R: 140, G: 86, B: 75, A: 255},
+	color.RGBA{R: 227, G: 119, B: 194, A: 255},
+	color.RGBA{R: 127, G: 127, B: 127, A: 255},
+}
+
+var (
+	orphanColor  = color.RGBA{R: 214, G: 39, B: 40, A: 255}
+	deadEndColor = color.RGBA{R: 255, G: 165, B: 0, A: 255}
+	linkedColor  = color.RGBA{R: 160, G: 160, B: 160, A: 255}
+)
+
+// showAnalytics analyses the workspace, summarises the report and lets the user colour
+// the nodes by one of its metrics. onColor is called with the colours by node id, or nil
+// to restore the type colours.
+func showAnalytics(useCase *usecase.NodeUseCase, w fyne.Window, onColor func(map[string]color.Color)) {
+	report, err := useCase.Analyze(context.Background())
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+
+	var central []string
+	for i, n := range report.Ranking {
+		if i == analyticsTopNodes {
+			break
+		}
+		central = append(central, fmt.Sprintf("%s (%.3f)", n.Title, report.PageRank[n.ID]))
+	}
+	summary := widget.NewLabel(strings.Join([]string{
+		"Most central: " + strings.Join(central, ", "),
+		fmt.Sprintf("Components: %d", len(report.Components)),
+		fmt.Sprintf("Communities: %d", len(report.Communities)),
+		fmt.Sprintf("Orphans (%d): %s", len(report.Orphans), nodeTitles(report.Orphans)),
+		fmt.Sprintf("Dead ends (%d): %s", len(report.DeadEnds), nodeTitles(report.DeadEnds)),
+	}, "\n"))
+	summary.Wrapping = fyne.TextWrapWord
+	modeSelect := widget.NewSelect(colorModes, nil)
+	modeSelect.SetSelected(colorByType)
+
+	content := container.NewVBox(summary, widget.NewForm(widget.NewFormItem("Colour by", modeSelect)))
+	d := dialog.NewCustomConfirm("Analytics", "Apply", "Close", content, func(apply bool) {
+		if apply {
+			onColor(analyticsColors(report, modeSelect.Selected))
+		}
+	}, w)
+	d.Resize(fyne.NewSize(480, 320))
+	d.Show()
+}
+
+// Helper function: node colours for a colour mode, nil for colorByType.
+func analyticsColors(report *analytics.Report, mode string) map[string]color.Color {
+	colors := make(map[string]color.Color)
+	switch mode {
+	case colorByCommunity, colorByComponent:
+		groups := report.Communities
+		if mode == colorByComponent {
+			groups = report.Components
+		}
+		for i, group := range groups {
+			c := groupPalette[min(i, len(groupPalette)-1)]
+			for _, n := range group {
+				colors[n.ID] = c
+			}
+		}
+	case colorByPageRank:
+		var top float64
+		for _, rank := range report.PageRank {
+			top = max(top, rank)
+		}
+		for id, rank := range report.PageRank {
+			// Shade from pale to saturated blue by the share of the top score.
+			shade := uint8(220 * (1 - rank/top))
+			colors[id] = color.RGBA{R: shade, G: shade, B: 255, A: 255}
+		}
+	case colorByDeadEnds:
+		for id := range report.Degrees {
+			colors[id] = linkedColor
+		}
+		for _, n := range report.Orphans {
+			colors[n.ID] = orphanColor
+		}
+		for _, n := range report.DeadEnds {
+			colors[n.ID] = deadEndColor
+		}
+	default:
+		return nil
+	}
+	return colors
+}
+
+// Helper function: join the titles of nodes with commas.
+func nodeTitles(nodes []*domain.Node) string {
+	titles := make([]string, len(nodes))
+	for i, n := range nodes {
+		titles[i] = n.Title
+	}
+	return strings.Join(titles, ", ")
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// runAsync runs work in the background while a dialog titled title shows an activity
+// bar and a Cancel button. Cancel cancels the context of work and closes the dialog.
+// Only work runs in the background; it must not touch widgets. When it succeeds and was
+// not cancelled, done is called back on the UI thread to apply the result; otherwise the
+// error is shown there unless work was cancelled. A cancelled change may still have been
+// saved.
+func runAsync(w fyne.Window, title string, work func(ctx context.Context) error, done func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var progress *dialog.CustomDialog
+	cancelButton := widget.NewButton("Cancel", func() {
+		cancel()
+		progress.Hide()
+	})
+	progress = dialog.NewCustomWithoutButtons(title, container.NewVBox(widget.NewProgressBarInfinite(), cancelButton), w)
+	progress.Show()
+
+	go func() {
+		defer cancel()
+		err := work(ctx)
+		cancelled := ctx.Err() != nil
+		fyne.Do(func() {
+			progress.Hide()
+			switch {
+			case cancelled:
+				// Cancelled, the dialog is closed already.
+			case err != nil:
+				dialog.ShowError(err, w)
+			default:
+				done()
+			}
+		})
+	}()
+}
@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// detailPanel shows the selected node next to the graph instead of a modal dialog, so the
+// graph stays usable while the node is edited. Its tabs hold the fields of the node, which
+// Save stores, its content as Markdown, its outgoing and incoming relationships and
+// backlinks, which select the node at their other end, and its former versions.
+// The links, suggestions and versions are loaded in the background whenever the panel is
+// reloaded. The dock button moves the panel to the other side of the graph.
+type detailPanel struct {
+	useCase    *usecase.NodeUseCase
+	types      *domain.TypeRegistry
+	window     fyne.Window
+	node       *domain.Node
+	heading    *widget.Label
+	title      *widget.Entry
+	typeSelect *widget.Select
+	tags       *widget.Entry
+	properties *propertyForm
+	timestamps *widget.Label
+	text       *contentPanel
+	links      *fyne.Container
+	history    *fyne.Container
+	tabs       *container.AppTabs
+	actions    *fyne.Container
+	content    *fyne.Container
+	// cancelLoad cancels the loading started by the last Reload; loading is done once the
+	// loaded details are shown or dropped.
+	cancelLoad context.CancelFunc
+	loading    sync.WaitGroup
+
+	onSelect func(*domain.Node)
+	onSaved  func(*domain.Node)
+	onDelete func(*domain.Node)
+	onLinked func(*domain.Relationship)
+	onDock   func()
+}
+
+// newDetailPanel creates the panel without a node. onSelect is called with the node at the
+// other end of a tapped relationship or backlink, onLink with the title of a tapped
+// [[Title]] link, onSaved with the node after it was saved, onDelete after it was deleted,
+// onLinked with a relationship created from a suggested link and onDock when the dock
+// button is tapped.
+func newDetailPanel(useCase *usecase.NodeUseCase, types *domain.TypeRegistry, w fyne.Window, onSelect func(*domain.Node), onLink func(string),
+	onSaved, onDelete func(*domain.Node), onLinked func(*domain.Relationship), onDock func()) *detailPanel {
+	p := &detailPanel{
+		useCase:    useCase,
+		types:      types,
+		window:     w,
+		heading:    widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		title:      widget.NewEntry(),
+		tags:       widget.NewEntry(),
+		properties: newPropertyForm(),
+		timestamps: widget.NewLabel(""),
+		links:      container.NewVBox(),
+		history:    container.NewVBox(),
+		onSelect:   onSelect,
+		onSaved:    onSaved,
+		onDelete:   onDelete,
+		onLinked:   onLinked,
+		onDock:     onDock,
+	}
+	p.heading.Truncation = fyne.TextTruncateEllipsis
+	p.typeSelect = widget.NewSelect(nil, func(selected string) {
+		if p.node == nil {
+			return
+		}
+		def, _ := p.types.NodeType(domain.NodeType(selected))
+		p.properties.SetSchema(def.Properties, p.node.Properties)
+	})
+	p.text = newContentPanel(useCase, w, onLink, func(n *domain.Node) {
+		p.Reload()
+		if p.onSaved != nil {
+			p.onSaved(n)
+		}
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Title", p.title),
+		widget.NewFormItem("Type", p.typeSelect),
+		widget.NewFormItem("Tags", p.tags),
+		widget.NewFormItem("Properties", p.properties.content),
+	)
+	p.tags.SetPlaceHolder("comma separated, a/b for nested")
+	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), p.save)
+	deleteBtn := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), p.confirmDelete)
+	p.actions = container.NewGridWithColumns(2, saveBtn, deleteBtn)
+	fields := container.NewVBox(form, p.timestamps)
+
+	p.tabs = container.NewAppTabs(
+		container.NewTabItem("Fields", container.NewVScroll(fields)),
+		container.NewTabItem("Content", p.text.content),
+		container.NewTabItem("Links", container.NewVScroll(p.links)),
+		container.NewTabItem("History", container.NewVScroll(p.history)),
+	)
+	dockBtn := widget.NewButtonWithIcon("", theme.ViewRestoreIcon(), func() {
+		if p.onDock != nil {
+			p.onDock()
+		}
+	})
+	closeBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() { p.Show(nil) })
+	header := container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(dockBtn, closeBtn), p.heading),
+		p.actions,
+	)
+	p.content = container.NewBorder(header, nil, nil, nil, p.tabs)
+	p.Show(nil)
+	return p
+}
+
+// SetServices points the panel at the use case and types of another workspace, or at
+// edited types, and clears it.
+func (p *detailPanel) SetServices(useCase *usecase.NodeUseCase, types *domain.TypeRegistry) {
+	p.useCase, p.types = useCase, types
+	p.text.SetUseCase(useCase)
+	p.Show(nil)
+}
+
+// Show fills the panel with n, nil clears it.
+func (p *detailPanel) Show(n *domain.Node) {
+	p.node = n
+	p.text.Show(n)
+	p.Reload()
+}
+
+// Node returns the shown node, nil if there is none.
+func (p *detailPanel) Node() *domain.Node {
+	return p.node
+}
+
+// Reload shows the shown node again, e.g. after it was edited elsewhere. Unsaved changes
+// to its fields are discarded.
+func (p *detailPanel) Reload() {
+	p.text.Reload()
+	if p.cancelLoad != nil {
+		p.cancelLoad()
+		p.cancelLoad = nil
+	}
+	if p.node == nil {
+		p.heading.SetText("No node selected")
+		p.actions.Hide()
+		p.tabs.Hide()
+		return
+	}
+	p.heading.SetText(p.node.Title)
+	p.title.SetText(p.node.Title)
+	p.typeSelect.Options = p.types.NodeTypeNames()
+	p.typeSelect.SetSelected(string(p.node.Type))
+	def, _ := p.types.NodeType(p.node.Type)
+	p.properties.SetSchema(def.Properties, p.node.Properties)
+	p.tags.SetText(strings.Join(p.node.Tags, ", "))
+	p.timestamps.SetText(fmt.Sprintf("Created %s\nUpdated %s", formatTime(p.node.CreatedAt), formatTime(p.node.UpdatedAt)))
+	p.actions.Show()
+	p.tabs.Show()
+	p.load(p.node)
+}
+
+// save stores the edited fields of the node.
+func (p *detailPanel) save() {
+	if p.node == nil {
+		return
+	}
+	previous := *p.node
+	p.node.Title = p.title.Text
+	p.node.Type = domain.NodeType(p.typeSelect.Selected)
+	p.node.Tags = parseTags(p.tags.Text)
+	p.node.Properties = p.properties.Values()
+	if err := p.useCase.UpdateNode(context.Background(), p.node); err != nil {
+		*p.node = previous
+		dialog.ShowError(err, p.window)
+		return
+	}
+	p.Reload()
+	if p.onSaved != nil {
+		p.onSaved(p.node)
+	}
+}
+
+// confirmDelete deletes the node once the user confirms it.
+func (p *detailPanel) confirmDelete() {
+	n := p.node
+	if n == nil {
+		return
+	}
+	dialog.ShowConfirm("Delete Node", fmt.Sprintf("Are you sure you want to delete %q?", n.Title), func(confirm bool) {
+		if !confirm {
+			return
+		}
+		if err := p.useCase.DeleteNode(context.Background(), n.ID); err != nil {
+			dialog.ShowError(err, p.window)
+			return
+		}
+		if p.node == n {
+			p.Show(nil)
+		}
+		if p.onDelete != nil {
+			p.onDelete(n)
+		}
+	}, p.window)
+}
+
+// nodeDetails is what the panel loads for a node in the background. The error of each
+// part is shown in place of that part.
+type nodeDetails struct {
+	relationships    []*domain.Relationship
+	relationshipsErr error
+	// nodes holds every node of the workspace by id, to show the other ends of the
+	// relationships.
+	nodes        map[string]*domain.Node
+	backlinks    []*domain.Node
+	backlinksErr error
+	suggestions  []analytics.Suggestion
+	suggestErr   error
+	revisions    []domain.NodeRevision
+	historyErr   error
+}
+
+// loadNodeDetails loads the relationships, backlinks, suggested links and former versions
+// of the node id. The titles at the other ends of the relationships come from a single
+// list of the nodes.
+func loadNodeDetails(ctx context.Context, useCase *usecase.NodeUseCase, id string) nodeDetails {
+	var d nodeDetails
+	d.relationships, d.relationshipsErr = useCase.ListRelationships(ctx, id)
+	if d.relationshipsErr == nil && len(d.relationships) > 0 {
+		nodes, err := useCase.SearchNodes(ctx, "", "")
+		if err != nil {
+			d.relationshipsErr = err
+		}
+		d.nodes = make(map[string]*domain.Node, len(nodes))
+		for _, n := range nodes {
+			d.nodes[n.ID] = n
+		}
+	}
+	d.backlinks, d.backlinksErr = useCase.Backlinks(ctx, id)
+	d.suggestions, d.suggestErr = useCase.SuggestLinks(ctx, id, suggestionLimit)
+	d.revisions, d.historyErr = useCase.NodeHistory(ctx, id)
+	return d
+}
+
+// load shows placeholders for the links and the history of n, loads them in the
+// background and shows them unless the panel was reloaded meanwhile.
+func (p *detailPanel) load(n *domain.Node) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelLoad = cancel
+	for _, box := range []*fyne.Container{p.links, p.history} {
+		box.Objects = []fyne.CanvasObject{widget.NewLabel("Loading...")}
+		box.Refresh()
+	}
+	useCase := p.useCase
+	p.loading.Add(1)
+	go func() {
+		defer p.loading.Done()
+		d := loadNodeDetails(ctx, useCase, n.ID)
+		fyne.Do(func() {
+			// A later Reload cancels the context on the UI thread, so this check is not racy.
+			if ctx.Err() != nil {
+				return
+			}
+			cancel()
+			p.showLinks(n, d)
+			p.showHistory(d)
+		})
+	}()
+}
+
+// showLinks lists the relationships and backlinks of n and the suggested links.
+func (p *detailPanel) showLinks(n *domain.Node, d nodeDetails) {
+	var outgoing, incoming []fyne.CanvasObject
+	if d.relationshipsErr != nil {
+		outgoing = append(outgoing, widget.NewLabel("Relationships unavailable: "+d.relationshipsErr.Error()))
+	} else {
+		for _, rel := range d.relationships {
+			if rel.SourceID == n.ID {
+				outgoing = append(outgoing, p.nodeButton(string(rel.Type)+" → ", rel.TargetIDs[0], d.nodes))
+			}
+			if rel.TargetIDs[0] == n.ID {
+				incoming = append(incoming, p.nodeButton(string(rel.Type)+" ← ", rel.SourceID, d.nodes))
+			}
+		}
+	}
+
+	var backlinks []fyne.CanvasObject
+	if d.backlinksErr != nil {
+		backlinks = append(backlinks, widget.NewLabel("Backlinks unavailable: "+d.backlinksErr.Error()))
+	}
+	for _, linking := range d.backlinks {
+		linking := linking
+		backlinks = append(backlinks, linkButton(linking.Title, func() { p.onSelect(linking) }))
+	}
+
+	p.links.Objects = nil
+	addSection(p.links, "Outgoing", outgoing)
+	addSection(p.links, "Incoming", incoming)
+	addSection(p.links, "Backlinks", backlinks)
+	p.links.Add(suggestedLinks(p.useCase, n, d.suggestions, d.suggestErr, p.window, p.onLinked))
+	p.links.Refresh()
+}
+
+// nodeButton is a button labelled with prefix and the title of the node id in nodes that
+// selects it.
+func (p *detailPanel) nodeButton(prefix, id string, nodes map[string]*domain.Node) fyne.CanvasObject {
+	n, ok := nodes[id]
+	if !ok {
+		return widget.NewLabel(prefix + id + " (not found)")
+	}
+	return linkButton(prefix+n.Title, func() { p.onSelect(n) })
+}
+
+// showHistory lists the former versions of the node, newest first.
+func (p *detailPanel) showHistory(d nodeDetails) {
+	p.history.Objects = nil
+	switch {
+	case d.historyErr != nil:
+		p.history.Add(widget.NewLabel("History unavailable: " + d.historyErr.Error()))
+	case len(d.revisions) == 0:
+		p.history.Add(widget.NewLabel("No earlier versions"))
+	default:
+		accordion := widget.NewAccordion()
+		for _, rev := range d.revisions {
+			details := widget.NewLabel(fmt.Sprintf("Title: %s\nType: %s\nTags: %s", rev.Title, rev.Type, strings.Join(rev.Tags, ", ")))
+			body := container.NewVBox(details, renderMarkdown(rev.Content, p.text.onLink))
+			accordion.Append(widget.NewAccordionItem(formatTime(rev.SavedAt)+" "+rev.Title, body))
+		}
+		p.history.Add(accordion)
+	}
+	p.history.Refresh()
+}
+
+// Helper function: add a titled section listing items to box, or "None".
+func addSection(box *fyne.Container, title string, items []fyne.CanvasObject) {
+	box.Add(widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	if len(items) == 0 {
+		items = []fyne.CanvasObject{widget.NewLabel("None")}
+	}
+	for _, item := range items {
+		box.Add(item)
+	}
+}
+
+// Helper function: a flat, left-aligned button that reads like a link.
+func linkButton(text string, tapped func()) *widget.Button {
+	btn := widget.NewButton(text, tapped)
+	btn.Alignment = widget.ButtonAlignLeading
+	btn.Importance = widget.LowImportance
+	return btn
+}
+
+// Helper function: a timestamp in local time, "never" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+// panelDock holds a panel on either side of the graph. left and right are placed beside
+// the graph; the panel starts on the right.
+type panelDock struct {
+	panel       fyne.CanvasObject
+	left, right *fyne.Container
+}
+
+func newPanelDock(panel fyne.CanvasObject) *panelDock {
+	return &panelDock{
+		panel: panel,
+		left:  container.NewStack(),
+		right: container.NewStack(panel),
+	}
+}
+
+// Toggle moves the panel to the other side.
+func (d *panelDock) Toggle() {
+	from, to := d.right, d.left
+	if len(d.left.Objects) > 0 {
+		from, to = d.left, d.right
+	}
+	from.Objects = nil
+	to.Objects = []fyne.CanvasObject{d.panel}
+	from.Refresh()
+	to.Refresh()
+}
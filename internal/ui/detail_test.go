@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"context"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestDetailPanel(t *testing.T) {
+	a := test.NewTempApp(t)
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	useCase := usecase.NewNodeUseCase(repo, repo)
+
+	node := &domain.Node{Title: "Closures", Type: domain.Concept}
+	id, err := useCase.CreateNode(ctx, node)
+	assert.NoError(t, err, "CreateNode should succeed")
+	node.ID = id
+	linking := &domain.Node{Title: "Functions", Content: "See [[Closures]].", Type: domain.Note}
+	linking.ID, err = useCase.CreateNode(ctx, linking)
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = useCase.CreateRelationship(ctx, &domain.Relationship{SourceID: node.ID, TargetIDs: []string{linking.ID}, Type: domain.RelatedTo})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	var selected, saved *domain.Node
+	panel := newDetailPanel(useCase, domain.NewTypeRegistry(nil, nil), a.NewWindow("test"),
+		func(n *domain.Node) { selected = n }, func(string) {},
+		func(n *domain.Node) { saved = n }, nil, nil, nil)
+	panel.Show(node)
+	panel.loading.Wait()
+
+	var buttons []*widget.Button
+	for _, obj := range panel.links.Objects {
+		if btn, ok := obj.(*widget.Button); ok {
+			buttons = append(buttons, btn)
+		}
+	}
+	if assert.Len(t, buttons, 2, "Expected the outgoing relationship and the backlink") {
+		assert.Equal(t, "RELATED_TO → Functions", buttons[0].Text)
+		test.Tap(buttons[1])
+		if assert.NotNil(t, selected, "Tapping a backlink should select its node") {
+			assert.Equal(t, linking.ID, selected.ID)
+		}
+	}
+
+	panel.title.SetText("Closures in Go")
+	panel.save()
+	panel.loading.Wait()
+	if assert.NotNil(t, saved, "Saving should report the node") {
+		assert.Equal(t, "Closures in Go", saved.Title)
+	}
+	revisions, err := useCase.NodeHistory(ctx, node.ID)
+	assert.NoError(t, err, "NodeHistory should succeed")
+	if assert.Len(t, revisions, 1, "Saving should keep the former version") {
+		assert.Equal(t, "Closures", revisions[0].Title)
+	}
+	_, ok := panel.history.Objects[0].(*widget.Accordion)
+	assert.True(t, ok, "The history should list the former version")
+}
@@ -4,51 +4,70 @@ import (
 	"context"
 	"fmt"
 	"image/color"
-	"math"
-	"math/rand"
 	"strings"
-	"time"
 
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/app"
+	fyneapp "fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/AndrivA89/neo4j-go-playground/internal/app"
 	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
 	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
 )
 
+// nodeWidth and nodeHeight are the unzoomed size of a node widget: a circle of
+// nodeHeight followed by the title.
+const (
+	nodeWidth  = 160
+	nodeHeight = 40
+)
+
 // Edge represents a relationship between two nodes.
 type Edge struct {
 	ID   string
 	From *domain.Node
 	To   *domain.Node
 	Type string
+	// Relationship is the stored relationship with its attributes, nil if unknown.
+	Relationship *domain.Relationship
 }
 
 // NodeWidget is a custom widget to display a Node along with edit functionality.
 type NodeWidget struct {
 	widget.BaseWidget
-	Node         *domain.Node
-	Pos          fyne.Position
-	ParentWindow fyne.Window
-	UseCase      *usecase.NodeUseCase
-	OnDelete     func(*domain.Node)
-	OnUpdate     func(*domain.Node)
+	Node  *domain.Node
+	Pos   fyne.Position
+	Types *domain.TypeRegistry
+	// OnExpand is called on a double-tap to load the neighbours of the node, nil ignores it.
+	OnExpand func(*domain.Node)
+	// OnMoved is called with the new position when the user drops the dragged node.
+	OnMoved func(*domain.Node, fyne.Position)
+	// OnSelect is called when the node is tapped.
+	OnSelect func(*domain.Node)
+	// Fill replaces the colour of the node type if set, e.g. to show a metric.
+	Fill color.Color
+	// Highlighted draws the node with a highlight border, e.g. when it lies on a found path.
+	Highlighted bool
+	// Scale is the zoom of the graph; below labelZoom the title is hidden.
+	Scale float32
+	// Style sets the shape and colour of the node; an empty colour keeps the colour of the type.
+	Style domain.NodeStyle
+	// Growth enlarges the shape, e.g. for nodes with many links.
+	Growth float32
 }
 
-// NewNodeWidget creates a new NodeWidget.
-func NewNodeWidget(n *domain.Node, pos fyne.Position, w fyne.Window, uc *usecase.NodeUseCase, onDelete, onUpdate func(*domain.Node)) *NodeWidget {
+// NewNodeWidget creates a new NodeWidget. types decides the colour and the icon.
+func NewNodeWidget(n *domain.Node, pos fyne.Position, types *domain.TypeRegistry) *NodeWidget {
 	nw := &NodeWidget{
-		Node:         n,
-		Pos:          pos,
-		ParentWindow: w,
-		UseCase:      uc,
-		OnDelete:     onDelete,
-		OnUpdate:     onUpdate,
+		Node:   n,
+		Pos:    pos,
+		Types:  types,
+		Scale:  1,
+		Growth: 1,
 	}
 	nw.ExtendBaseWidget(nw)
 	return nw
@@ -56,187 +75,337 @@ func NewNodeWidget(n *domain.Node, pos fyne.Position, w fyne.Window, uc *usecase
 
 // CreateRenderer implements the widget.Renderer interface.
 func (nw *NodeWidget) CreateRenderer() fyne.WidgetRenderer {
-	// Create circle representing the node.
-	circle := canvas.NewCircle(color.RGBA{R: 0, G: 0, B: 255, A: 255})
-	circle.StrokeWidth = 2
-	circle.StrokeColor = color.White
-	circle.FillColor = color.RGBA{R: 0, G: 0, B: 255, A: 255}
-	circle.Resize(fyne.NewSize(40, 40))
-	circle.Move(fyne.NewPos(0, 0))
+	return &nodeWidgetRenderer{node: nw, objects: nw.objects()}
+}
 
-	// Create label to display node title.
-	label := widget.NewLabel(nw.Node.Title)
-	label.Move(fyne.NewPos(45, 10))
-	label.Resize(fyne.NewSize(120, 20))
+// objects draws the node as it is currently configured.
+func (nw *NodeWidget) objects() []fyne.CanvasObject {
+	def, _ := nw.Types.NodeType(nw.Node.Type)
 
-	objects := []fyne.CanvasObject{circle, label}
-	return &nodeWidgetRenderer{objects: objects}
+	// Create the shape representing the node, coloured by its type.
+	fillHex := nw.Style.Color
+	if fillHex == "" {
+		fillHex = def.Color
+	}
+	fill := parseHexColor(fillHex, defaultNodeColor)
+	if nw.Fill != nil {
+		fill = nw.Fill
+	}
+	size := nodeHeight * nw.Scale * nw.Growth
+	shape := nodeShape(nw.Style.Shape, fill, size)
+	if nw.Highlighted {
+		highlightShape(shape)
+	}
+
+	objects := []fyne.CanvasObject{shape}
+	// Create label to display node title.
+	if nw.Scale >= labelZoom {
+		label := widget.NewLabel(nw.Node.Title)
+		label.Move(fyne.NewPos(size+5, size/2-10))
+		label.Resize(fyne.NewSize(120, 20))
+		objects = append(objects, label)
+	}
+	if icon := typeIcon(def.Icon); icon != nil {
+		img := canvas.NewImageFromResource(theme.NewInvertedThemedResource(icon))
+		img.Resize(fyne.NewSize(size/2, size/2))
+		img.Move(fyne.NewPos(size/4, size/4))
+		objects = append(objects, img)
+	}
+	return objects
 }
 
+// nodeWidgetRenderer draws the node again on every refresh, so that a widget reused for
+// an edited node shows its new title, type and style.
 type nodeWidgetRenderer struct {
+	node    *NodeWidget
 	objects []fyne.CanvasObject
 }
 
 func (r *nodeWidgetRenderer) Layout(_ fyne.Size) {}
-func (r *nodeWidgetRenderer) MinSize() fyne.Size { return fyne.NewSize(160, 40) }
+func (r *nodeWidgetRenderer) MinSize() fyne.Size { return fyne.NewSize(nodeWidth, nodeHeight) }
 func (r *nodeWidgetRenderer) Refresh() {
-	for _, obj := range r.objects {
-		obj.Refresh()
-	}
+	r.objects = r.node.objects()
+	canvas.Refresh(r.node)
 }
 func (r *nodeWidgetRenderer) BackgroundColor() color.Color { return color.Transparent }
 func (r *nodeWidgetRenderer) Objects() []fyne.CanvasObject { return r.objects }
 func (r *nodeWidgetRenderer) Destroy()                     {}
 
-// Tapped opens an edit dialog for the node.
+// Tapped selects the node, which shows it in the detail panel.
 func (nw *NodeWidget) Tapped(_ *fyne.PointEvent) {
-	var pop dialog.Dialog
-
-	// Create form entries pre-populated with node data.
-	titleEntry := widget.NewEntry()
-	titleEntry.SetText(nw.Node.Title)
-	contentEntry := widget.NewMultiLineEntry()
-	contentEntry.SetText(nw.Node.Content)
-	typeSelect := widget.NewSelect([]string{
-		string(domain.Concept), string(domain.Note), string(domain.References),
-	}, nil)
-	typeSelect.SetSelected(string(nw.Node.Type))
-	tagsEntry := widget.NewEntry()
-	tagsEntry.SetText(strings.Join(nw.Node.Tags, ", "))
-
-	formItems := []*widget.FormItem{
-		widget.NewFormItem("Title", titleEntry),
-		widget.NewFormItem("Content", contentEntry),
-		widget.NewFormItem("Type", typeSelect),
-		widget.NewFormItem("Tags (comma separated)", tagsEntry),
+	if nw.OnSelect != nil {
+		nw.OnSelect(nw.Node)
 	}
-	form := widget.NewForm(formItems...)
-	// Disable default buttons.
-	form.SubmitText = ""
-	form.CancelText = ""
-
-	// Create custom buttons.
-	updateBtn := widget.NewButton("Update", func() {
-		nw.Node.Title = titleEntry.Text
-		nw.Node.Content = contentEntry.Text
-		nw.Node.Type = domain.NodeType(typeSelect.Selected)
-		nw.Node.Tags = parseTags(tagsEntry.Text)
-		err := nw.UseCase.UpdateNode(context.Background(), nw.Node)
-		if err != nil {
-			dialog.ShowError(err, nw.ParentWindow)
-		} else {
-			if nw.OnUpdate != nil {
-				nw.OnUpdate(nw.Node)
-			}
-		}
-		pop.Hide()
-	})
-	deleteBtn := widget.NewButton("Delete", func() {
-		dialog.ShowConfirm("Delete Node", "Are you sure you want to delete this node?", func(confirm bool) {
-			if confirm {
-				err := nw.UseCase.DeleteNode(context.Background(), nw.Node.ID)
-				if err != nil {
-					dialog.ShowError(err, nw.ParentWindow)
-				} else {
-					if nw.OnDelete != nil {
-						nw.OnDelete(nw.Node)
-					}
-				}
-				pop.Hide()
-			}
-		}, nw.ParentWindow)
-	})
-
-	cancelBtn := widget.NewButton("Cancel", func() {
-		pop.Hide()
-	})
-
-	btnBar := container.New(layout.NewGridLayoutWithColumns(3), updateBtn, deleteBtn, cancelBtn)
-	dialogContent := container.NewVBox(form, btnBar)
-
-	pop = dialog.NewCustomWithoutButtons("Edit Node", dialogContent, nw.ParentWindow)
-	pop.Show()
 }
 
 func (nw *NodeWidget) TappedSecondary(_ *fyne.PointEvent) {}
 
-// buildGraphContainer builds and returns a new container with nodes and edges.
-func buildGraphContainer(useCase *usecase.NodeUseCase, nodes []*domain.Node, edges []Edge, w fyne.Window, onDelete, onUpdate func(*domain.Node)) *fyne.Container {
-	graph := container.NewWithoutLayout()
-	positions := generatePositions(nodes, 100, 500, 500, 80)
-	// Draw edges.
-	for _, edge := range edges {
-		if posFrom, ok1 := positions[edge.From.ID]; ok1 {
-			if posTo, ok2 := positions[edge.To.ID]; ok2 {
-				line := canvas.NewLine(color.Black)
-				centerOffset := fyne.NewPos(20, 20)
-				line.Position1 = posFrom.Add(centerOffset)
-				line.Position2 = posTo.Add(centerOffset)
-				line.StrokeWidth = 2
-				graph.Add(line)
-			}
-		}
+// DoubleTapped expands the neighbourhood of the node.
+func (nw *NodeWidget) DoubleTapped(_ *fyne.PointEvent) {
+	if nw.OnExpand != nil {
+		nw.OnExpand(nw.Node)
 	}
-	// Draw nodes.
-	for _, n := range nodes {
-		if pos, ok := positions[n.ID]; ok {
-			nodeW := NewNodeWidget(n, pos, w, useCase, onDelete, onUpdate)
-			nodeW.Move(pos)
-			nodeW.Resize(fyne.NewSize(160, 40))
-			graph.Add(nodeW)
-		}
+}
+
+// Dragged moves the node along with the pointer. Edges follow when the node is dropped.
+func (nw *NodeWidget) Dragged(ev *fyne.DragEvent) {
+	pos := nw.Pos.Add(ev.Dragged)
+	nw.Pos = fyne.NewPos(max(pos.X, 0), max(pos.Y, 0))
+	nw.Move(nw.Pos)
+}
+
+// DragEnd reports the position the node was dropped at.
+func (nw *NodeWidget) DragEnd() {
+	if nw.OnMoved != nil {
+		nw.OnMoved(nw.Node, nw.Pos)
 	}
-	return graph
 }
 
 // ShowGraphUI displays the graph UI with search and management functionalities.
-func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdges []Edge) {
-	a := app.New()
-	w := a.NewWindow("Neo4j Go Playground")
+// graphTheme sets how node and relationship types are drawn.
+func ShowGraphUI(services app.Services, nodes []*domain.Node, initialEdges []Edge, workspaces app.Workspaces, graphTheme domain.GraphTheme) {
+	useCase := services.Nodes
+	a := fyneapp.New()
+	w := a.NewWindow(windowTitle(useCase))
 	w.Resize(fyne.NewSize(800, 600))
 
-	// allNodes holds the complete list of nodes.
-	allNodes := nodes
-	// filteredNodes holds the current filtered list.
-	filteredNodes := nodes
-	// filteredEdges holds the current edges.
-	filteredEdges := initialEdges
-	// allEdges holds all relationships
-	allEdges := initialEdges
-
-	scrollContainer := container.NewScroll(container.NewWithoutLayout())
-	scrollContainer.SetMinSize(fyne.NewSize(800, 600))
+	// state holds the shown nodes and edges; every action reads and changes them through it,
+	// also from background operations.
+	state := newUIState(nodes, initialEdges)
+
+	// neighborhoodFilter holds the relationships last chosen under Neighbourhood; double-tapping
+	// a node loads its neighbours along them.
+	var neighborhoodFilter domain.NeighborhoodFilter
+	// lastMerge is the most recent merge, which Undo Merge reverts.
+	var lastMerge *domain.MergeRecord
+	var undoMergeButton *widget.Button
+	var layoutSelect *widget.Select
+	// placement holds the chosen layout and the node positions.
+	placement := newGraphLayout()
+	// style holds the visual encoding by type, switched in the legend.
+	style := newGraphStyle(graphTheme)
+	var legendPanel *legend
+	// views is the Views menu; dragged nodes are saved to the view open in it.
+	var views *viewsMenu
+
+	// registry holds the node and relationship types of the workspace.
+	registry := loadRegistry(services.Types, w)
+
+	// graphView shows the graph; zooming updates it through redraw, which runs on the UI
+	// thread like every change to widgets.
+	var redraw func()
+	graphView := newZoomCanvas(placement, func() { redraw() })
 
 	var onDeleteCallback func(*domain.Node)
 	var onUpdateCallback func(*domain.Node)
+	var onEdgeUpdateCallback func(*domain.Relationship)
+	var onExpandCallback func(*domain.Node)
+	var onMovedCallback func(*domain.Node, fyne.Position)
+	var tags *tagsPanel
+	// detail shows the selected node beside the graph; dock holds it on the left or right.
+	var detail *detailPanel
+	var dock *panelDock
 
 	// onDeleteCallback removes the deleted node and its edges and rebuilds the graph.
 	onDeleteCallback = func(deletedNode *domain.Node) {
-		allNodes = filterNodes(allNodes, func(n *domain.Node) bool { return n.ID != deletedNode.ID })
-		filteredNodes = filterNodes(filteredNodes, func(n *domain.Node) bool { return n.ID != deletedNode.ID })
-		filteredEdges = filterEdges(filteredEdges, deletedNode.ID)
-		newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-		scrollContainer.Content = newGraph
-		scrollContainer.Refresh()
+		state.Update(func(g *graphData) { g.removeNode(deletedNode.ID) })
+		if selected := detail.Node(); selected != nil && selected.ID == deletedNode.ID {
+			detail.Show(nil)
+		}
+		redraw()
 		w.Content().Refresh()
+		tags.Reload()
 	}
 
 	// onUpdateCallback rebuilds the graph after a node update.
 	onUpdateCallback = func(updatedNode *domain.Node) {
-		newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-		scrollContainer.Content = newGraph
-		scrollContainer.Refresh()
+		redraw()
 		w.Content().Refresh()
+		tags.Reload()
+		detail.Reload()
+	}
+
+	// addEdge adds an edge for a relationship that is not shown yet, together with its
+	// nodes ends.
+	addEdge := func(rel *domain.Relationship, ends []*domain.Node) {
+		edge := Edge{ID: rel.ID, From: ends[0], To: ends[1], Type: string(rel.Type), Relationship: rel}
+		state.Update(func(g *graphData) {
+			g.allNodes = mergeNodes(g.allNodes, ends)
+			g.shownNodes = mergeNodes(g.shownNodes, ends)
+			g.addEdges([]Edge{edge})
+			g.loadedEdges = append(g.loadedEdges, edge)
+		})
+	}
+
+	// onEdgeUpdateCallback applies an edited relationship to the edges, or adds a new one
+	// together with its nodes, and rebuilds the graph. Nodes the window does not know yet
+	// are loaded in the background.
+	onEdgeUpdateCallback = func(rel *domain.Relationship) {
+		apply := func() {
+			state.Update(func(g *graphData) {
+				updateEdges(g.allEdges, rel)
+				updateEdges(g.shownEdges, rel)
+				updateEdges(g.loadedEdges, rel)
+			})
+			onUpdateCallback(nil)
+		}
+		g := state.Snapshot()
+		if containsEdge(g.allEdges, rel.ID) {
+			apply()
+			return
+		}
+		ends := []*domain.Node{findNodeByID(g.allNodes, rel.SourceID), findNodeByID(g.allNodes, rel.TargetIDs[0])}
+		if ends[0] != nil && ends[1] != nil {
+			addEdge(rel, ends)
+			apply()
+			return
+		}
+		lookup := useCase
+		runAsync(w, "Loading nodes", func(ctx context.Context) error {
+			for i, id := range []string{rel.SourceID, rel.TargetIDs[0]} {
+				if ends[i] != nil {
+					continue
+				}
+				var err error
+				if ends[i], err = lookup.GetNode(ctx, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, func() {
+			addEdge(rel, ends)
+			apply()
+		})
 	}
 
-	// Build initial graph.
-	graphContainer := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-	scrollContainer.Content = graphContainer
+	// showLoadedNeighborhood shows a loaded neighbourhood. With replace it becomes the
+	// shown graph, otherwise its nodes and edges are added to the shown ones.
+	showLoadedNeighborhood := func(n *domain.Neighborhood, replace bool) {
+		state.Update(func(g *graphData) {
+			// Keep the node values already known so that existing edges still refer to them.
+			for i, node := range n.Nodes {
+				if known := findNodeByID(g.allNodes, node.ID); known != nil {
+					n.Nodes[i] = known
+				}
+			}
+			edges := edgesFromNeighborhood(n, registry)
+			g.allNodes = mergeNodes(g.allNodes, n.Nodes)
+			g.allEdges = mergeEdges(g.allEdges, edges)
+			g.loadedEdges = mergeEdges(g.loadedEdges, edges)
+			if replace {
+				g.shownNodes = n.Nodes
+				g.shownEdges = edges
+			} else {
+				g.shownNodes = mergeNodes(g.shownNodes, n.Nodes)
+				g.shownEdges = mergeEdges(g.shownEdges, edges)
+			}
+		})
+		onUpdateCallback(nil)
+	}
+
+	// showNeighborhood loads the neighbourhood of start in the background and shows it.
+	showNeighborhood := func(start *domain.Node, depth int, replace bool) {
+		load, filter := useCase, neighborhoodFilter
+		var n *domain.Neighborhood
+		runAsync(w, "Loading neighbourhood", func(ctx context.Context) error {
+			var err error
+			n, err = load.GetNeighborhood(ctx, start.ID, depth, filter)
+			return err
+		}, func() { showLoadedNeighborhood(n, replace) })
+	}
+
+	// onExpandCallback adds the direct neighbours of a double-tapped node to the graph.
+	onExpandCallback = func(n *domain.Node) {
+		showNeighborhood(n, 1, false)
+	}
+
+	// onMovedCallback pins a dropped node, saves its position to the open view and redraws
+	// its edges.
+	onMovedCallback = func(n *domain.Node, pos fyne.Position) {
+		placement.Pin(n.ID, pos)
+		redraw()
+		save, view := services.Views, views.Current()
+		runAsync(w, "Saving position", func(ctx context.Context) error {
+			return save.MoveNode(ctx, view, n.ID, domain.Position{X: float64(pos.X), Y: float64(pos.Y)})
+		}, func() {})
+	}
+
+	// Build initial graph. model keeps the widgets on the canvas and redraw only updates
+	// what changed.
+	model := newGraphModel(placement, style)
+	model.window = w
+	model.onEdgeUpdate, model.onExpand, model.onMoved = onEdgeUpdateCallback, onExpandCallback, onMovedCallback
+	model.onSelect = func(n *domain.Node) { detail.Show(n) }
+	model.SetServices(useCase, registry)
+	redraw = func() {
+		model.Show(state.Snapshot(), graphView)
+	}
+	redraw()
+
+	// selectNode shows n in the detail panel, adds it to the graph if it is hidden and
+	// scrolls to it.
+	selectNode := func(n *domain.Node) {
+		state.Update(func(g *graphData) {
+			if known := findNodeByID(g.allNodes, n.ID); known != nil {
+				n = known
+			} else {
+				g.allNodes = append(g.allNodes, n)
+			}
+			if !containsNode(g.shownNodes, n) {
+				g.shownNodes = append(g.shownNodes, n)
+			}
+		})
+		redraw()
+		if center, ok := model.NodeCenter(n.ID); ok {
+			graphView.centerOn(center)
+		}
+		detail.Show(n)
+	}
+
+	// openLink navigates to the node a [[Title]] link refers to, or offers to create it.
+	openLink := func(title string) {
+		resolve := useCase
+		var target *domain.Node
+		runAsync(w, "Opening link", func(ctx context.Context) error {
+			var err error
+			target, err = resolve.NodeByTitle(ctx, title)
+			return err
+		}, func() {
+			if target != nil {
+				selectNode(target)
+				return
+			}
+			dialog.ShowConfirm("Create Node", fmt.Sprintf("There is no node titled %q. Create it?", title), func(confirm bool) {
+				if !confirm {
+					return
+				}
+				created := &domain.Node{Title: title, Type: domain.Concept}
+				runAsync(w, "Adding node", func(ctx context.Context) error {
+					id, err := resolve.CreateNode(ctx, created)
+					created.ID = id
+					return err
+				}, func() {
+					selectNode(created)
+					tags.Reload()
+				})
+			}, w)
+		})
+	}
+	detail = newDetailPanel(useCase, registry, w, selectNode, openLink, onUpdateCallback, onDeleteCallback, onEdgeUpdateCallback,
+		func() { dock.Toggle() })
+	dock = newPanelDock(detail.content)
+
+	// showSearchResults replaces the shown nodes with results and keeps the edges between them.
+	showSearchResults := func(results []*domain.Node) {
+		state.Update(func(g *graphData) { g.showResults(results) })
+		redraw()
+		w.Content().Refresh()
+	}
 
 	// --- Search UI ---
 	searchEntry := widget.NewEntry()
-	searchEntry.SetPlaceHolder("Enter search query...")
-	searchSelect := widget.NewSelect([]string{"Tag", "Title/Content", "All"}, nil)
+	searchEntry.SetPlaceHolder("Enter search query... (name:value for properties)")
+	searchSelect := widget.NewSelect([]string{"Tag", "Title/Content", "Property", "All"}, nil)
 	searchSelect.SetSelected("All")
 	searchButton := widget.NewButton("Search", func() {
 		if searchEntry.Text == "" {
@@ -245,55 +414,179 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 		query := strings.TrimSpace(searchEntry.Text)
 		criteria := searchSelect.Selected
 		// Call the search method in the usecase layer.
-		results, err := useCase.SearchNodes(context.Background(), query, criteria)
-		if err != nil {
-			dialog.ShowError(err, w)
-			return
-		}
-		// Update filteredNodes and rebuild graph accordingly.
-		filteredNodes = results
-		// For edges, you might want to decide how to update them (e.g., only show edges where both nodes are in results).
-		var newEdges []Edge
-		for _, e := range initialEdges {
-			if containsNode(filteredNodes, e.From) && containsNode(filteredNodes, e.To) {
-				newEdges = append(newEdges, e)
-			}
-		}
-		filteredEdges = newEdges
-
-		newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-		scrollContainer.Content = newGraph
-		scrollContainer.Refresh()
-		w.Content().Refresh()
+		search := useCase
+		var results []*domain.Node
+		runAsync(w, "Searching", func(ctx context.Context) error {
+			var err error
+			results, err = search.SearchNodes(ctx, query, criteria)
+			return err
+		}, func() {
+			showSearchResults(results)
+		})
 	})
 	resetButton := widget.NewButton("Reset", func() {
-		if searchEntry.Text == "" {
+		if searchEntry.Text == "" && state.Snapshot().highlight == nil {
 			return
 		}
-		filteredNodes = allNodes
-		filteredEdges = allEdges
-		newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-		scrollContainer.Content = newGraph
-		scrollContainer.Refresh()
+		state.Update(func(g *graphData) {
+			g.showAll()
+			g.highlight = nil
+		})
+		redraw()
 		w.Content().Refresh()
 		searchEntry.SetText("")
 	})
 	firstRow := container.NewBorder(nil, nil, searchSelect, nil, searchEntry)
 	secondRow := container.NewAdaptiveGrid(2, searchButton, resetButton)
-	searchContainer := container.NewVBox(firstRow, secondRow)
+
+	// --- Time travel ---
+	// An empty date shows every relationship again.
+	asOfEntry := widget.NewEntry()
+	asOfEntry.SetPlaceHolder("Show links valid on " + domain.PropertyDateLayout)
+	asOfButton := widget.NewButton("Apply", func() {
+		at, err := parseOptionalDate("date", asOfEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		state.Update(func(g *graphData) { g.asOf = at })
+		onUpdateCallback(nil)
+	})
+	// --- Layout ---
+	// Choosing a layout, even the current one, lays out every node again.
+	layoutSelect = widget.NewSelect(layoutNames, func(name string) {
+		placement.SetLayout(name)
+		onUpdateCallback(nil)
+	})
+	layoutSelect.Selected = layoutNames[0]
+	// --- Zoom ---
+	// The mouse wheel zooms at the pointer and dragging the background pans.
+	zoomInButton := widget.NewButtonWithIcon("", theme.ZoomInIcon(), graphView.ZoomIn)
+	zoomOutButton := widget.NewButtonWithIcon("", theme.ZoomOutIcon(), graphView.ZoomOut)
+	fitButton := widget.NewButtonWithIcon("", theme.ZoomFitIcon(), graphView.Fit)
+	timeRow := container.NewBorder(nil, nil, widget.NewLabel("As of"),
+		container.NewHBox(asOfButton, widget.NewLabel("Layout"), layoutSelect, zoomOutButton, zoomInButton, fitButton), asOfEntry)
+
+	// --- Workspace switcher ---
+	// Switching reloads every node and the types of the new workspace in the background.
+	// Relationships are not loaded. openWorkspace shows what was loaded.
+	openWorkspace := func(switched app.Services, loaded []*domain.Node, types *domain.TypeRegistry) {
+		services = switched
+		useCase = switched.Nodes
+		registry = types
+		model.SetServices(useCase, registry)
+		legendPanel.SetRegistry(registry)
+		tags.SetUseCase(switched.Tags)
+		detail.SetServices(useCase, registry)
+		state.Update(func(g *graphData) {
+			*g = graphData{allNodes: loaded, shownNodes: loaded}
+		})
+		neighborhoodFilter = domain.NeighborhoodFilter{}
+		lastMerge = nil
+		undoMergeButton.Disable()
+		placement.SetLayout(layoutSelect.Selected)
+		searchEntry.SetText("")
+		w.SetTitle(windowTitle(useCase))
+		// Opening the default view of the workspace restores its positions and rebuilds the graph.
+		views.SetUseCase(switched.Views)
+	}
+
+	workspaceRow := newWorkspaceSwitcher(useCase, workspaces, w, func(switched app.Services) {
+		var loaded []*domain.Node
+		var types *domain.TypeRegistry
+		runAsync(w, "Opening workspace", func(ctx context.Context) error {
+			var err error
+			if loaded, err = switched.Nodes.SearchNodes(ctx, "", ""); err != nil {
+				return err
+			}
+			types, err = switched.Types.Registry(ctx)
+			return err
+		}, func() { openWorkspace(switched, loaded, types) })
+	})
+	searchContainer := container.NewVBox(workspaceRow, firstRow, secondRow, timeRow)
+
+	// --- Tags panel ---
+	// Selecting a tag shows exactly the nodes carrying it, like a tag search.
+	tags = newTagsPanel(services.Tags, w, func(name string) {
+		filter := services.Tags
+		var results []*domain.Node
+		runAsync(w, "Searching", func(ctx context.Context) error {
+			var err error
+			results, err = filter.NodesByTag(ctx, name)
+			return err
+		}, func() {
+			searchSelect.SetSelected("Tag")
+			searchEntry.SetText(name)
+			showSearchResults(results)
+		})
+	}, func(oldName, newName string) {
+		state.Update(func(g *graphData) { renameNodeTags(g.allNodes, oldName, newName) })
+		onUpdateCallback(nil)
+	}, func(sources []string, target string) {
+		state.Update(func(g *graphData) { retagNodes(g.allNodes, sources, target) })
+		onUpdateCallback(nil)
+	})
+
+	// --- Views ---
+	// Opening a view repeats its search and restores its layout, positions and zoom.
+	views = newViewsMenu(services.Views, w, func() domain.View {
+		return domain.View{
+			Query:     strings.TrimSpace(searchEntry.Text),
+			Criteria:  searchSelect.Selected,
+			Layout:    placement.Name(),
+			Positions: placement.Positions(),
+			Zoom:      placement.Zoom(),
+		}
+	}, func(view *domain.View) {
+		placement.SetZoom(view.Zoom)
+		if indexOf(layoutNames, view.Layout) >= 0 {
+			layoutSelect.Selected = view.Layout
+			layoutSelect.Refresh()
+			placement.SetLayout(view.Layout)
+		}
+		placement.SetPinned(view.Positions)
+		state.Update(func(g *graphData) { g.highlight = nil })
+		if view.Query == "" {
+			searchEntry.SetText("")
+			state.Update(func(g *graphData) { g.showAll() })
+			redraw()
+			return
+		}
+		search := useCase
+		var results []*domain.Node
+		runAsync(w, "Searching", func(ctx context.Context) error {
+			var err error
+			results, err = search.SearchNodes(ctx, view.Query, view.Criteria)
+			return err
+		}, func() {
+			searchSelect.SetSelected(view.Criteria)
+			searchEntry.SetText(view.Query)
+			showSearchResults(results)
+		})
+	})
+	views.Open(domain.DefaultView)
+	w.SetMainMenu(fyne.NewMainMenu(views.menu))
+
+	// --- Legend ---
+	legendPanel = newLegend(style, registry, redraw)
 
 	// --- Top Buttons ---
 	addNodeButton := widget.NewButton("Add Node", func() {
 		titleEntry := widget.NewEntry()
 		contentEntry := widget.NewMultiLineEntry()
-		typeSelect := widget.NewSelect([]string{"CONCEPT", "NOTE", "REFERENCE"}, nil)
-		typeSelect.SetSelected("CONCEPT")
+		properties := newPropertyForm()
+		typeSelect := widget.NewSelect(registry.NodeTypeNames(), func(selected string) {
+			def, _ := registry.NodeType(domain.NodeType(selected))
+			properties.SetSchema(def.Properties, nil)
+		})
+		typeSelect.SetSelected(string(domain.Concept))
 		tagsEntry := widget.NewEntry()
 		formItems := []*widget.FormItem{
 			widget.NewFormItem("Title", titleEntry),
 			widget.NewFormItem("Content", contentEntry),
 			widget.NewFormItem("Type", typeSelect),
-			widget.NewFormItem("Tags (comma separated)", tagsEntry),
+			widget.NewFormItem("Tags (comma separated, a/b for nested)", tagsEntry),
+			widget.NewFormItem("Properties", properties.content),
 		}
 		dialog.ShowForm("Add New Node", "Submit", "Cancel", formItems, func(valid bool) {
 			if !valid {
@@ -305,25 +598,25 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 				Content: contentEntry.Text,
 				Type:    domain.NodeType(typeSelect.Selected),
 				Tags:    parseTags(tagsEntry.Text),
+
+				Properties: properties.Values(),
 			}
 
-			go func() {
-				id, err := useCase.CreateNode(context.Background(), newNode)
-				if err != nil {
-					dialog.ShowError(err, w)
-					return
-				}
+			create := useCase
+			runAsync(w, "Adding node", func(ctx context.Context) error {
+				id, err := create.CreateNode(ctx, newNode)
 				newNode.ID = id
-				allNodes = append(allNodes, newNode)
-				filteredNodes = allNodes
-				newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-				scrollContainer.Content = newGraph
-				scrollContainer.Refresh()
+				return err
+			}, func() {
+				state.Update(func(g *graphData) { g.addNode(newNode) })
+				redraw()
 				w.Content().Refresh()
-			}()
+				tags.Reload()
+			})
 		}, w)
 	})
 	addRelButton := widget.NewButton("Add Relationship", func() {
+		allNodes := state.Snapshot().allNodes
 		sourceOptions := make([]string, len(allNodes))
 		for i, n := range allNodes {
 			sourceOptions[i] = n.Title
@@ -340,18 +633,17 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 		}
 
 		targetCheckGroup := widget.NewCheckGroup(targetOptions, nil)
-		relTypeSelect := widget.NewSelect([]string{
-			string(domain.RelatedTo), string(domain.References), string(domain.IsPartOf),
-			string(domain.HasPart), string(domain.DependsOn), string(domain.IsPrecededBy),
-		}, nil)
+		relTypeSelect := widget.NewSelect(registry.RelationTypeNames(), nil)
 		relTypeSelect.SetSelected(string(domain.RelatedTo))
 		descEntry := widget.NewEntry()
+		attributes := newRelationshipAttributes(nil)
 		formItems := []*widget.FormItem{
 			widget.NewFormItem("Source Node", sourceSelect),
 			widget.NewFormItem("Target Nodes", targetCheckGroup),
 			widget.NewFormItem("Relationship Type", relTypeSelect),
 			widget.NewFormItem("Description", descEntry),
 		}
+		formItems = append(formItems, attributes.FormItems()...)
 		dialog.ShowForm("Add New Relationship", "Submit", "Cancel", formItems, func(valid bool) {
 			if !valid {
 				return
@@ -382,18 +674,23 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 				Type:        domain.RelationType(relTypeSelect.Selected),
 				Description: descEntry.Text,
 			}
+			if err := attributes.Apply(newRel); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
 
-			go func() {
-				createdIDs, err := useCase.CreateRelationship(context.Background(), newRel)
-				if err != nil {
-					dialog.ShowError(err, w)
-					return
-				}
-
+			create := useCase
+			var createdIDs []string
+			runAsync(w, "Adding relationship", func(ctx context.Context) error {
+				var err error
+				createdIDs, err = create.CreateRelationship(ctx, newRel)
+				return err
+			}, func() {
 				if len(createdIDs) != len(targetIDs) {
 					fmt.Printf("Warning: createdIDs length (%d) does not match targetIDs length (%d)\n", len(createdIDs), len(targetIDs))
 				}
 
+				var newEdges []Edge
 				for i, relID := range createdIDs {
 					if i >= len(targetIDs) {
 						break
@@ -409,33 +706,35 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 					}
 
 					if targetNode != nil {
+						stored := *newRel
+						stored.ID = relID
+						stored.TargetIDs = []string{tID}
 						newEdge := Edge{
-							ID:   relID,
-							From: sourceNode,
-							To:   targetNode,
-							Type: relTypeSelect.Selected,
+							ID:           relID,
+							From:         sourceNode,
+							To:           targetNode,
+							Type:         string(newRel.Type),
+							Relationship: &stored,
 						}
-
-						allEdges = append(allEdges, newEdge)
-						filteredEdges = append(filteredEdges, newEdge)
+						newEdges = append(newEdges, newEdge)
 					}
 				}
 
-				newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-				scrollContainer.Content = newGraph
-				scrollContainer.Refresh()
+				state.Update(func(g *graphData) { g.addEdges(newEdges) })
+				redraw()
 				w.Content().Refresh()
-			}()
+			})
 		}, w)
 	})
 	removeRelButton := widget.NewButton("Remove Relationship", func() {
-		if len(filteredEdges) == 0 {
+		shownEdges := state.Snapshot().shownEdges
+		if len(shownEdges) == 0 {
 			dialog.ShowInformation("No relationships", "No relationships to remove", w)
 			return
 		}
 
 		var edgeOptions []string
-		for i, e := range filteredEdges {
+		for i, e := range shownEdges {
 			label := fmt.Sprintf("[%d] %s -> %s (%s)", i, e.From.Title, e.To.Title, e.Type)
 			edgeOptions = append(edgeOptions, label)
 		}
@@ -459,86 +758,137 @@ func ShowGraphUI(useCase *usecase.NodeUseCase, nodes []*domain.Node, initialEdge
 				return
 			}
 
-			edge := filteredEdges[idx]
-			if edge.ID != "" {
-				err := useCase.DeleteRelationship(context.Background(), edge.ID)
-				if err != nil {
-					dialog.ShowError(err, w)
-					return
+			edge := shownEdges[idx]
+			remove := useCase
+			runAsync(w, "Removing relationship", func(ctx context.Context) error {
+				if edge.ID == "" {
+					return nil
 				}
-			}
-
-			filteredEdges = append(filteredEdges[:idx], filteredEdges[idx+1:]...)
-			newGraph := buildGraphContainer(useCase, filteredNodes, filteredEdges, w, onDeleteCallback, onUpdateCallback)
-			scrollContainer.Content = newGraph
-			scrollContainer.Refresh()
-			w.Content().Refresh()
+				return remove.DeleteRelationship(ctx, edge.ID)
+			}, func() {
+				// DeleteRelationship also deleted the inverse edge, if the type has one.
+				def, _ := registry.RelationType(domain.RelationType(edge.Type))
+				state.Update(func(g *graphData) {
+					g.removeEdges(func(e Edge) bool {
+						return e.ID == edge.ID || def.Inverse != "" && e.Type == string(def.Inverse) && e.From.ID == edge.To.ID && e.To.ID == edge.From.ID
+					})
+				})
+				redraw()
+				w.Content().Refresh()
+			})
 		}, w)
 	})
 
-	topButtons := container.NewAdaptiveGrid(3, addNodeButton, addRelButton, removeRelButton)
-	content := container.NewBorder(searchContainer, topButtons, nil, nil, scrollContainer)
-	w.SetContent(content)
-	w.ShowAndRun()
-}
+	typesButton := widget.NewButton("Types", func() {
+		showTypesDialog(services.Types, w, func(reloaded *domain.TypeRegistry) {
+			registry = reloaded
+			model.SetServices(useCase, registry)
+			legendPanel.SetRegistry(registry)
+			selected := detail.Node()
+			detail.SetServices(useCase, registry)
+			detail.Show(selected)
+			onUpdateCallback(nil)
+		})
+	})
 
-// Helper function: generate random positions for nodes.
-func generatePositions(nodes []*domain.Node, minDist float64, width, height, maxAttempts int) map[string]fyne.Position {
-	randomize := rand.New(rand.NewSource(time.Now().UnixNano()))
-	positions := make(map[string]fyne.Position, len(nodes))
+	checkLinksButton := widget.NewButton("Check Links", func() {
+		showInverseCheck(useCase, state.Snapshot().allNodes, w, func() { onUpdateCallback(nil) })
+	})
 
-	for _, n := range nodes {
-		var pos fyne.Position
-		attempt := 0
-
-		for {
-			attempt++
-			x := randomize.Intn(width) + 50
-			y := randomize.Intn(height) + 50
-			candidate := fyne.NewPos(float32(x), float32(y))
-			if !tooClose(candidate, positions, minDist) {
-				pos = candidate
-				break
-			}
+	findPathsButton := widget.NewButton("Find Paths", func() {
+		g := state.Snapshot()
+		showFindPaths(useCase, registry, g.allNodes, g.asOf, w, func(paths []domain.Path) {
+			state.Update(func(g *graphData) {
+				g.highlight = &pathHighlight{paths: paths}
+				// Show every node of the paths even if a search has hidden it.
+				for _, n := range g.allNodes {
+					if g.highlight.hasNode(n.ID) && !containsNode(g.shownNodes, n) {
+						g.shownNodes = append(g.shownNodes, n)
+					}
+				}
+			})
+			onUpdateCallback(nil)
+		})
+	})
 
-			if attempt > maxAttempts {
-				pos = candidate
-				break
-			}
-		}
-		positions[n.ID] = pos
-	}
-	return positions
-}
+	readingListButton := widget.NewButton("Reading List", func() {
+		showReadingList(useCase, state.Snapshot().allNodes, w, showSearchResults)
+	})
 
-// Helper function: check if candidate position is too close to existing ones.
-func tooClose(candidate fyne.Position, existing map[string]fyne.Position, minDist float64) bool {
-	for _, pos := range existing {
-		if distance(candidate, pos) < minDist {
-			return true
-		}
-	}
-	return false
+	neighborhoodButton := widget.NewButton("Neighbourhood", func() {
+		showNeighborhoodDialog(registry, state.Snapshot().allNodes, neighborhoodFilter, w, func(start *domain.Node, depth int, filter domain.NeighborhoodFilter) {
+			neighborhoodFilter = filter
+			showNeighborhood(start, depth, true)
+		})
+	})
+
+	analyticsButton := widget.NewButton("Analytics", func() {
+		showAnalytics(useCase, w, func(colors map[string]color.Color) {
+			state.Update(func(g *graphData) { g.colors = colors })
+			onUpdateCallback(nil)
+		})
+	})
+
+	duplicatesButton := widget.NewButton("Duplicates", func() {
+		showDuplicates(useCase, w, func(record *domain.MergeRecord, survivor *domain.Node) {
+			state.Update(func(g *graphData) {
+				g.removeEdges(func(e Edge) bool { return indexOf(record.Dropped, e.ID) >= 0 })
+				g.replaceNode(survivor, record.Merged.ID)
+			})
+			lastMerge = record
+			undoMergeButton.Enable()
+			onUpdateCallback(nil)
+		})
+	})
+
+	undoMergeButton = widget.NewButton("Undo Merge", func() {
+		record, undo := lastMerge, useCase
+		runAsync(w, "Undoing merge", func(ctx context.Context) error {
+			return undo.UndoMerge(ctx, record)
+		}, func() {
+			state.Update(func(g *graphData) {
+				survivor := *record.Survivor
+				g.replaceNode(&survivor)
+				restored := *record.Merged
+				g.allNodes = mergeNodes(g.allNodes, []*domain.Node{&restored})
+				g.shownNodes = mergeNodes(g.shownNodes, []*domain.Node{&restored})
+				moved := func(e Edge) bool {
+					for _, rel := range record.Relationships {
+						if rel.ID == e.ID {
+							return true
+						}
+					}
+					return false
+				}
+				g.removeEdges(moved)
+				edges := edgesFromNeighborhood(&domain.Neighborhood{Nodes: g.allNodes, Relationships: record.Relationships}, registry)
+				g.allEdges = mergeEdges(g.allEdges, edges)
+				g.shownEdges = mergeEdges(g.shownEdges, edges)
+				g.loadedEdges = mergeEdges(g.loadedEdges, edges)
+			})
+			lastMerge = nil
+			undoMergeButton.Disable()
+			onUpdateCallback(nil)
+		})
+	})
+	undoMergeButton.Disable()
+
+	topButtons := container.NewAdaptiveGrid(6, addNodeButton, addRelButton, removeRelButton, typesButton, checkLinksButton,
+		findPathsButton, readingListButton, neighborhoodButton, analyticsButton, duplicatesButton, undoMergeButton)
+	left := container.NewBorder(legendPanel.content, nil, nil, nil, dock.left)
+	right := container.NewBorder(nil, nil, dock.right, nil, tags.content)
+	w.SetContent(container.NewBorder(searchContainer, topButtons, left, right, graphView.content))
+	w.ShowAndRun()
 }
 
-// Helper function: calculate Euclidean distance.
-func distance(a, b fyne.Position) float64 {
-	dx := float64(a.X - b.X)
-	dy := float64(a.Y - b.Y)
-	return math.Sqrt(dx*dx + dy*dy)
+// Helper function: window title showing the active workspace.
+func windowTitle(useCase *usecase.NodeUseCase) string {
+	return "Neo4j Go Playground - " + useCase.Workspace().Name
 }
 
-// Helper function: parse comma-separated tags.
+// Helper function: parse comma-separated tags. Hierarchical tags use "/" between levels.
 func parseTags(tagsStr string) []string {
-	tags := strings.Split(tagsStr, ",")
-	var result []string
-	for _, tag := range tags {
-		trimmed := strings.TrimSpace(tag)
-		if trimmed != "" {
-			result = append(result, trimmed)
-		}
-	}
-	return result
+	return domain.NormalizeTags(strings.Split(tagsStr, ","))
 }
 
 // Helper function: return index of a string in a slice.
@@ -582,3 +932,34 @@ func containsNode(list []*domain.Node, node *domain.Node) bool {
 	}
 	return false
 }
+
+// Helper function: find a node of the list by id, nil if it is not in the list.
+func findNodeByID(list []*domain.Node, id string) *domain.Node {
+	for _, n := range list {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+// Helper function: remove the edges matching drop.
+func removeEdges(edges []Edge, drop func(Edge) bool) []Edge {
+	var result []Edge
+	for _, e := range edges {
+		if !drop(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Helper function: check if an edge with the id is in the list.
+func containsEdge(list []Edge, id string) bool {
+	for _, e := range list {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// showInverseCheck lists the relationships whose inverse edge is missing and offers to
+// create the missing edges. onRepaired is called after a successful repair.
+func showInverseCheck(useCase *usecase.NodeUseCase, nodes []*domain.Node, w fyne.Window, onRepaired func()) {
+	mismatches, err := useCase.CheckInverses(context.Background())
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	if len(mismatches) == 0 {
+		dialog.ShowInformation("Check Links", "Every relationship has its inverse", w)
+		return
+	}
+
+	titles := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		titles[n.ID] = n.Title
+	}
+	title := func(id string) string {
+		if t, ok := titles[id]; ok {
+			return t
+		}
+		return id
+	}
+	lines := make([]string, 0, len(mismatches))
+	for _, m := range mismatches {
+		rel := m.Relationship
+		lines = append(lines, fmt.Sprintf("%s -%s-> %s: missing %s",
+			title(rel.SourceID), rel.Type, title(rel.TargetIDs[0]), m.Missing))
+	}
+	report := widget.NewLabel(strings.Join(lines, "\n"))
+
+	dialog.ShowCustomConfirm("Check Links", "Repair", "Close", report, func(repair bool) {
+		if !repair {
+			return
+		}
+		repaired, err := useCase.RepairInverses(context.Background())
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		dialog.ShowInformation("Check Links", fmt.Sprintf("Created %d inverse relationships", repaired), w)
+		onRepaired()
+	}, w)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"math"
+	"sync"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/layout"
+)
+
+// layoutNames are the layouts offered by the layout select, the default first.
+var layoutNames = layout.Names
+
+// graphLayout remembers the chosen layout and where nodes were placed, so that rebuilding
+// the graph keeps the picture and only places nodes that were not shown before. Nodes the
+// user moved are pinned: the layout never moves them. Positions are kept unzoomed and
+// scaled by zoom when the graph is drawn. It is safe for concurrent use, so that the graph
+// can be drawn in the background.
+type graphLayout struct {
+	mu        sync.Mutex
+	name      string
+	positions map[string]layout.Point
+	pinned    map[string]layout.Point
+	zoom      float64
+	// shown holds the nodes of the last place call.
+	shown []string
+}
+
+func newGraphLayout() *graphLayout {
+	return &graphLayout{
+		name:      layoutNames[0],
+		positions: make(map[string]layout.Point),
+		pinned:    make(map[string]layout.Point),
+		zoom:      domain.DefaultZoom,
+	}
+}
+
+// SetZoom changes the zoom, limited to minZoom and maxZoom.
+func (l *graphLayout) SetZoom(zoom float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.zoom = math.Min(math.Max(zoom, minZoom), maxZoom)
+}
+
+// Zoom returns the zoom the graph is drawn at.
+func (l *graphLayout) Zoom() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.zoom
+}
+
+// Name returns the name of the chosen layout.
+func (l *graphLayout) Name() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.name
+}
+
+// bounds returns the smallest and largest unzoomed position of the shown nodes, false if
+// no node is shown.
+func (l *graphLayout) bounds() (lo, hi layout.Point, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, id := range l.shown {
+		p := l.positions[id]
+		if i == 0 {
+			lo, hi = p, p
+			continue
+		}
+		lo = layout.Point{X: math.Min(lo.X, p.X), Y: math.Min(lo.Y, p.Y)}
+		hi = layout.Point{X: math.Max(hi.X, p.X), Y: math.Max(hi.Y, p.Y)}
+	}
+	return lo, hi, len(l.shown) > 0
+}
+
+// SetLayout switches to the named layout and forgets every position except the pinned
+// ones, so that the next rebuild lays out all other nodes again.
+func (l *graphLayout) SetLayout(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.setLayout(name)
+}
+
+func (l *graphLayout) setLayout(name string) {
+	l.name = name
+	l.positions = make(map[string]layout.Point, len(l.pinned))
+	for id, p := range l.pinned {
+		l.positions[id] = p
+	}
+}
+
+// SetPinned replaces the pinned positions, e.g. with those of an opened view, and lays
+// out every other node again on the next rebuild.
+func (l *graphLayout) SetPinned(positions map[string]domain.Position) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pinned = make(map[string]layout.Point, len(positions))
+	for id, p := range positions {
+		l.pinned[id] = layout.Point{X: p.X, Y: p.Y}
+	}
+	l.setLayout(l.name)
+}
+
+// Pin keeps the node at pos, where the user dropped it on the zoomed graph.
+func (l *graphLayout) Pin(id string, pos fyne.Position) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p := layout.Point{X: float64(pos.X) / l.zoom, Y: float64(pos.Y) / l.zoom}
+	l.pinned[id] = p
+	l.positions[id] = p
+}
+
+// Positions returns the position of every node placed so far, to save them with a view.
+func (l *graphLayout) Positions() map[string]domain.Position {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	positions := make(map[string]domain.Position, len(l.positions))
+	for id, p := range l.positions {
+		positions[id] = domain.Position{X: p.X, Y: p.Y}
+	}
+	return positions
+}
+
+// place returns the top-left corner of every node widget at the current zoom. If all nodes were placed
+// before, they keep their positions; otherwise the layout runs incrementally from them
+// and moves every node but the pinned ones.
+func (l *graphLayout) place(nodes []*domain.Node, edges []Edge) map[string]fyne.Position {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ids := make([]string, len(nodes))
+	complete := true
+	for i, n := range nodes {
+		ids[i] = n.ID
+		if _, ok := l.positions[n.ID]; !ok {
+			complete = false
+		}
+	}
+	if !complete {
+		links := make([]layout.Link, len(edges))
+		for i, e := range edges {
+			links[i] = layout.Link{From: e.From.ID, To: e.To.ID}
+		}
+		width, height := layoutArea(len(nodes))
+		for id, p := range layout.ByName(l.name).Place(ids, links, l.positions, width, height) {
+			if _, ok := l.pinned[id]; !ok {
+				l.positions[id] = p
+			}
+		}
+	}
+
+	l.shown = ids
+	positions := make(map[string]fyne.Position, len(nodes))
+	for _, id := range ids {
+		p := l.positions[id]
+		positions[id] = fyne.NewPos(float32(p.X*l.zoom), float32(p.Y*l.zoom))
+	}
+	return positions
+}
+
+// Helper function: the area to lay out n nodes in, growing with the node count.
+func layoutArea(n int) (width, height float64) {
+	side := math.Sqrt(float64(n))
+	return math.Max(800, 220*side), math.Max(600, 160*side)
+}
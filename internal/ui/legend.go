@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+const (
+	swatchWidth  = 40
+	swatchHeight = 20
+)
+
+// legend explains the visual encoding: the shape and colour of every node type and the
+// line of every relationship type, with an arrowhead if it is directed. Its switches turn
+// the edge labels and sizing nodes by their links on and off.
+type legend struct {
+	style    *graphStyle
+	entries  *fyne.Container
+	content  fyne.CanvasObject
+	onChange func()
+}
+
+// newLegend creates the legend of the types in registry. onChange is called after a
+// switch was toggled, to draw the graph again.
+func newLegend(style *graphStyle, registry *domain.TypeRegistry, onChange func()) *legend {
+	l := &legend{
+		style:    style,
+		entries:  container.NewVBox(),
+		onChange: onChange,
+	}
+	labelsCheck := widget.NewCheck("Edge labels", func(on bool) {
+		l.style.edgeLabels.Store(on)
+		l.onChange()
+	})
+	labelsCheck.Checked = style.edgeLabels.Load()
+	degreeCheck := widget.NewCheck("Size by links", func(on bool) {
+		l.style.sizeByDegree.Store(on)
+		l.onChange()
+	})
+	degreeCheck.Checked = style.sizeByDegree.Load()
+
+	body := container.NewVBox(labelsCheck, degreeCheck, widget.NewSeparator(), container.NewVScroll(l.entries))
+	l.content = widget.NewAccordion(widget.NewAccordionItem("Legend", body))
+	l.SetRegistry(registry)
+	return l
+}
+
+// SetRegistry lists the types of another registry, e.g. after types were edited.
+func (l *legend) SetRegistry(registry *domain.TypeRegistry) {
+	var rows []fyne.CanvasObject
+	for _, def := range registry.NodeTypes {
+		style := l.style.theme.NodeStyle(def)
+		shape := nodeShape(style.Shape, parseHexColor(style.Color, defaultNodeColor), swatchHeight-4)
+		shape.Move(fyne.NewPos((swatchWidth-swatchHeight)/2+2, 2))
+		rows = append(rows, legendRow(string(def.Name), shape))
+	}
+	for _, def := range registry.RelationTypes {
+		style := l.style.theme.EdgeStyle(def.Name)
+		from, to := fyne.NewPos(2, swatchHeight/2), fyne.NewPos(swatchWidth-2, swatchHeight/2)
+		objects := styledLine(from, to, style, 1)
+		if def.Directed {
+			objects = append(objects, arrowHead(from, to, 0, parseHexColor(style.Color, color.Black))...)
+		}
+		rows = append(rows, legendRow(string(def.Name), objects...))
+	}
+	l.entries.Objects = rows
+	l.entries.Refresh()
+}
+
+// Helper function: a legend entry with a swatch drawn from objects and a name.
+func legendRow(name string, objects ...fyne.CanvasObject) fyne.CanvasObject {
+	size := canvas.NewRectangle(color.Transparent)
+	size.SetMinSize(fyne.NewSize(swatchWidth, swatchHeight))
+	swatch := container.NewWithoutLayout(append([]fyne.CanvasObject{size}, objects...)...)
+	return container.NewHBox(container.NewCenter(swatch), widget.NewLabel(name))
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"context"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+const (
+	contentPanelWidth = 280
+	// nodeLinkScheme is the URL scheme of rendered [[Title]] links.
+	nodeLinkScheme = "node"
+)
+
+// contentPanel shows the content of the selected node rendered as Markdown. The edit
+// toggle switches to a text editor whose changes Save stores. [[Title]] links in the
+// content call onLink with the linked title.
+type contentPanel struct {
+	useCase *usecase.NodeUseCase
+	window  fyne.Window
+	node    *domain.Node
+	editing bool
+	title   *widget.Label
+	body    *fyne.Container
+	editor  *widget.Entry
+	toggle  *widget.Button
+	save    *widget.Button
+	content fyne.CanvasObject
+	onLink  func(title string)
+	onSaved func(*domain.Node)
+}
+
+// newContentPanel creates the panel without a node. onLink is called with the title of
+// a tapped [[Title]] link and onSaved with the node after its content was saved.
+func newContentPanel(useCase *usecase.NodeUseCase, w fyne.Window, onLink func(string), onSaved func(*domain.Node)) *contentPanel {
+	p := &contentPanel{
+		useCase: useCase,
+		window:  w,
+		title:   widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		body:    container.NewStack(),
+		editor:  widget.NewMultiLineEntry(),
+		onLink:  onLink,
+		onSaved: onSaved,
+	}
+	p.title.Truncation = fyne.TextTruncateEllipsis
+	p.editor.Wrapping = fyne.TextWrapWord
+	p.editor.SetPlaceHolder("Markdown, [[Title]] links to another node")
+	p.toggle = widget.NewButtonWithIcon("Edit", theme.DocumentCreateIcon(), func() {
+		p.setEditing(!p.editing)
+	})
+	p.save = widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), p.saveContent)
+
+	header := container.NewVBox(
+		widget.NewLabelWithStyle("Content", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		p.title,
+		container.NewGridWithColumns(2, p.toggle, p.save),
+	)
+	scroll := container.NewVScroll(p.body)
+	scroll.SetMinSize(fyne.NewSize(contentPanelWidth, 0))
+	p.content = container.NewBorder(header, nil, nil, nil, scroll)
+	p.Show(nil)
+	return p
+}
+
+// SetUseCase points the panel at another workspace and clears it.
+func (p *contentPanel) SetUseCase(useCase *usecase.NodeUseCase) {
+	p.useCase = useCase
+	p.Show(nil)
+}
+
+// Show renders the content of n, nil clears the panel.
+func (p *contentPanel) Show(n *domain.Node) {
+	p.node = n
+	p.setEditing(false)
+}
+
+// Reload renders the content of the shown node again, e.g. after it was edited elsewhere.
+func (p *contentPanel) Reload() {
+	if !p.editing {
+		p.setEditing(false)
+	}
+}
+
+// Node returns the shown node, nil if there is none.
+func (p *contentPanel) Node() *domain.Node {
+	return p.node
+}
+
+// setEditing shows the editor or the rendered content of the node.
+func (p *contentPanel) setEditing(editing bool) {
+	p.editing = editing && p.node != nil
+	switch {
+	case p.node == nil:
+		p.title.SetText("No node selected")
+		p.body.Objects = nil
+	case p.editing:
+		p.editor.SetText(p.node.Content)
+		p.body.Objects = []fyne.CanvasObject{p.editor}
+	default:
+		p.title.SetText(p.node.Title)
+		p.body.Objects = []fyne.CanvasObject{renderMarkdown(p.node.Content, p.onLink)}
+	}
+	if p.editing {
+		p.toggle.SetText("Preview")
+		p.toggle.SetIcon(theme.VisibilityIcon())
+		p.save.Enable()
+	} else {
+		p.toggle.SetText("Edit")
+		p.toggle.SetIcon(theme.DocumentCreateIcon())
+		p.save.Disable()
+	}
+	if p.node == nil {
+		p.toggle.Disable()
+	} else {
+		p.toggle.Enable()
+	}
+	p.body.Refresh()
+}
+
+// saveContent stores the edited content and shows it rendered.
+func (p *contentPanel) saveContent() {
+	if p.node == nil {
+		return
+	}
+	previous := p.node.Content
+	p.node.Content = p.editor.Text
+	if err := p.useCase.UpdateNode(context.Background(), p.node); err != nil {
+		p.node.Content = previous
+		dialog.ShowError(err, p.window)
+		return
+	}
+	p.setEditing(false)
+	if p.onSaved != nil {
+		p.onSaved(p.node)
+	}
+}
+
+// renderMarkdown renders content as Markdown. [[Title]] links become hyperlinks that call
+// onLink with the title instead of opening a URL.
+func renderMarkdown(content string, onLink func(title string)) *widget.RichText {
+	markdown := domain.ReplaceWikiLinks(content, func(title string) string {
+		return "[" + title + "](" + nodeLinkScheme + ":" + url.PathEscape(title) + ")"
+	})
+	text := widget.NewRichTextFromMarkdown(markdown)
+	text.Wrapping = fyne.TextWrapWord
+	linkNodes(text.Segments, onLink)
+	return text
+}
+
+// Helper function: make the node links among segments and their children call onLink.
+func linkNodes(segments []widget.RichTextSegment, onLink func(string)) {
+	for _, segment := range segments {
+		switch s := segment.(type) {
+		case *widget.HyperlinkSegment:
+			if s.URL == nil || s.URL.Scheme != nodeLinkScheme {
+				continue
+			}
+			title, err := url.PathUnescape(s.URL.Opaque)
+			if err != nil {
+				continue
+			}
+			s.OnTapped = func() { onLink(title) }
+		case *widget.ListSegment:
+			linkNodes(s.Items, onLink)
+		case *widget.ParagraphSegment:
+			linkNodes(s.Texts, onLink)
+		}
+	}
+}
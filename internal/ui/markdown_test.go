@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	test.NewTempApp(t)
+	var opened []string
+	text := renderMarkdown("See [[Closures in Go]] and [the docs](https://go.dev).\n\n- [[Generics]]", func(title string) {
+		opened = append(opened, title)
+	})
+
+	var links []*widget.HyperlinkSegment
+	var collect func([]widget.RichTextSegment)
+	collect = func(segments []widget.RichTextSegment) {
+		for _, segment := range segments {
+			switch s := segment.(type) {
+			case *widget.HyperlinkSegment:
+				links = append(links, s)
+			case *widget.ListSegment:
+				collect(s.Items)
+			case *widget.ParagraphSegment:
+				collect(s.Texts)
+			}
+		}
+	}
+	collect(text.Segments)
+
+	if assert.Len(t, links, 3, "Expected the two node links and the web link") {
+		assert.Equal(t, "Closures in Go", links[0].Text)
+		assert.Nil(t, links[1].OnTapped, "A web link should open its URL")
+		for _, link := range links {
+			if link.OnTapped != nil {
+				link.OnTapped()
+			}
+		}
+	}
+	assert.Equal(t, []string{"Closures in Go", "Generics"}, opened)
+}
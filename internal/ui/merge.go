@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// showDuplicates lists the likely duplicate nodes. Choosing a pair opens the merge dialog;
+// onMerged is called with the record of a completed merge and the merged node as stored.
+func showDuplicates(useCase *usecase.NodeUseCase, w fyne.Window, onMerged func(*domain.MergeRecord, *domain.Node)) {
+	duplicates, err := useCase.FindDuplicates(context.Background())
+	if err != nil {
+		dialog.ShowError(err, w)
+		return
+	}
+	if len(duplicates) == 0 {
+		dialog.ShowInformation("Duplicates", "No duplicates found", w)
+		return
+	}
+
+	var pop dialog.Dialog
+	list := widget.NewList(
+		func() int { return len(duplicates) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			d := duplicates[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s / %s (%s, %.0f%%)", d.A.Title, d.B.Title, d.Reason, d.Score*100))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		pop.Hide()
+		showMergeDialog(useCase, duplicates[id], w, onMerged)
+	}
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(420, 260))
+	pop = dialog.NewCustom("Duplicates", "Close", scroll, w)
+	pop.Show()
+}
+
+// showMergeDialog asks which node survives and which title and content it keeps, then
+// merges the pair in the background and loads the surviving node.
+func showMergeDialog(useCase *usecase.NodeUseCase, d analytics.Duplicate, w fyne.Window, onMerged func(*domain.MergeRecord, *domain.Node)) {
+	pair := []*domain.Node{d.A, d.B}
+	options := []string{fmt.Sprintf("%s (older)", d.A.Title), d.B.Title}
+	survivorRadio := widget.NewRadioGroup(options, nil)
+	survivorRadio.SetSelected(options[0])
+	titleRadio := widget.NewRadioGroup(uniqueStrings(d.A.Title, d.B.Title), nil)
+	titleRadio.SetSelected(d.A.Title)
+	contentRadio := widget.NewRadioGroup([]string{"From " + options[0], "From " + d.B.Title}, nil)
+	contentRadio.SetSelected(contentRadio.Options[0])
+	for _, radio := range []*widget.RadioGroup{survivorRadio, titleRadio, contentRadio} {
+		radio.Required = true
+	}
+	if d.A.Content == "" && d.B.Content != "" {
+		contentRadio.SetSelected(contentRadio.Options[1])
+	}
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Keep node", survivorRadio),
+		widget.NewFormItem("Title", titleRadio),
+		widget.NewFormItem("Content", contentRadio),
+	}
+	dialog.ShowForm("Merge Nodes", "Merge", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		survivor, merged := pair[0], pair[1]
+		if indexOf(options, survivorRadio.Selected) == 1 {
+			survivor, merged = merged, survivor
+		}
+		opts := domain.MergeOptions{Title: titleRadio.Selected, Content: pair[indexOf(contentRadio.Options, contentRadio.Selected)].Content}
+		var record *domain.MergeRecord
+		var stored *domain.Node
+		runAsync(w, "Merging nodes", func(ctx context.Context) error {
+			var err error
+			if record, err = useCase.MergeNodes(ctx, survivor.ID, merged.ID, opts); err != nil {
+				return err
+			}
+			stored, err = useCase.GetNode(ctx, survivor.ID)
+			return err
+		}, func() { onMerged(record, stored) })
+	}, w)
+}
+
+// Helper function: the distinct values in order.
+func uniqueStrings(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if indexOf(result, v) < 0 {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// directionLabels are the choices of the direction select, in domain.Directions order.
+var directionLabels = []string{"Both", "Outgoing", "Incoming"}
+
+// showNeighborhoodDialog asks for a start node, a depth and the relationships to follow.
+// filter pre-fills the relationship choices. onShow is called with the chosen values; the
+// filter is also used when nodes are expanded by double-click.
+func showNeighborhoodDialog(registry *domain.TypeRegistry, nodes []*domain.Node, filter domain.NeighborhoodFilter, w fyne.Window, onShow func(start *domain.Node, depth int, filter domain.NeighborhoodFilter)) {
+	options := make([]string, len(nodes))
+	for i, n := range nodes {
+		options[i] = n.Title
+	}
+	startSelect := widget.NewSelect(options, nil)
+	depthEntry := widget.NewEntry()
+	depthEntry.SetText("1")
+	typesGroup := widget.NewCheckGroup(registry.RelationTypeNames(), nil)
+	for _, relType := range filter.RelationTypes {
+		typesGroup.Selected = append(typesGroup.Selected, string(relType))
+	}
+	directionSelect := widget.NewSelect(directionLabels, nil)
+	for i, d := range domain.Directions {
+		if d == filter.Direction {
+			directionSelect.SetSelected(directionLabels[i])
+		}
+	}
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Start Node", startSelect),
+		widget.NewFormItem(fmt.Sprintf("Depth (at most %d)", domain.MaxNeighborhoodDepth), depthEntry),
+		widget.NewFormItem("Relationship Types (none for all)", typesGroup),
+		widget.NewFormItem("Direction", directionSelect),
+	}
+	dialog.ShowForm("Neighbourhood", "Show", "Cancel", formItems, func(valid bool) {
+		idx := indexOf(options, startSelect.Selected)
+		if !valid || idx < 0 {
+			return
+		}
+		depth, err := strconv.Atoi(strings.TrimSpace(depthEntry.Text))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("depth must be a whole number"), w)
+			return
+		}
+		chosen := domain.NeighborhoodFilter{}
+		for _, name := range typesGroup.Selected {
+			chosen.RelationTypes = append(chosen.RelationTypes, domain.RelationType(name))
+		}
+		if i := indexOf(directionLabels, directionSelect.Selected); i >= 0 {
+			chosen.Direction = domain.Directions[i]
+		}
+		onShow(nodes[idx], depth, chosen)
+	}, w)
+}
+
+// Helper function: convert the relationships of a neighbourhood to edges. Of an edge and
+// its inverse edge only the one created first is kept, as the canvas shows one line per link.
+func edgesFromNeighborhood(n *domain.Neighborhood, registry *domain.TypeRegistry) []Edge {
+	byID := make(map[string]*domain.Node, len(n.Nodes))
+	for _, node := range n.Nodes {
+		byID[node.ID] = node
+	}
+	type link struct {
+		source, target string
+		relType        domain.RelationType
+	}
+	shown := make(map[link]bool)
+	var edges []Edge
+	for _, rel := range n.Relationships {
+		from, to := byID[rel.SourceID], byID[rel.TargetIDs[0]]
+		if from == nil || to == nil {
+			continue
+		}
+		if def, ok := registry.RelationType(rel.Type); ok && def.Inverse != "" && shown[link{to.ID, from.ID, def.Inverse}] {
+			continue
+		}
+		shown[link{from.ID, to.ID, rel.Type}] = true
+		edges = append(edges, Edge{ID: rel.ID, From: from, To: to, Type: string(rel.Type), Relationship: rel})
+	}
+	return edges
+}
+
+// Helper function: append the nodes that are not in list yet, matched by id. Nodes already
+// in list are kept, so that widgets and edges referring to them stay valid.
+func mergeNodes(list, nodes []*domain.Node) []*domain.Node {
+	known := make(map[string]bool, len(list))
+	for _, n := range list {
+		known[n.ID] = true
+	}
+	for _, n := range nodes {
+		if !known[n.ID] {
+			known[n.ID] = true
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// Helper function: append the edges that are not in list yet, matched by id.
+func mergeEdges(list, edges []Edge) []Edge {
+	known := make(map[string]bool, len(list))
+	for _, e := range list {
+		known[e.ID] = true
+	}
+	for _, e := range edges {
+		if !known[e.ID] {
+			known[e.ID] = true
+			list = append(list, e)
+		}
+	}
+	return list
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// highlightColor marks the nodes and links of highlighted paths.
+var highlightColor = color.RGBA{R: 255, G: 87, B: 34, A: 255}
+
+// pathHighlight holds the paths drawn on top of the graph. A nil highlight draws nothing.
+type pathHighlight struct {
+	paths []domain.Path
+}
+
+// hasNode reports whether the node lies on one of the paths.
+func (h *pathHighlight) hasNode(id string) bool {
+	if h == nil {
+		return false
+	}
+	for _, p := range h.paths {
+		for _, nodeID := range p.NodeIDs {
+			if nodeID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// links returns the pairs of consecutive nodes of all paths.
+func (h *pathHighlight) links() [][2]string {
+	if h == nil {
+		return nil
+	}
+	var links [][2]string
+	for _, p := range h.paths {
+		for i := 1; i < len(p.NodeIDs); i++ {
+			links = append(links, [2]string{p.NodeIDs[i-1], p.NodeIDs[i]})
+		}
+	}
+	return links
+}
+
+// showFindPaths asks for two nodes and the search options, and passes the found paths to
+// onFound. The search follows relationships in both directions for the fewest hops, or
+// along directed types for the lowest total weight, which is then reported. If asOf is
+// set, only relationships valid on that day are followed.
+func showFindPaths(useCase *usecase.NodeUseCase, registry *domain.TypeRegistry, nodes []*domain.Node, asOf *time.Time, w fyne.Window, onFound func([]domain.Path)) {
+	options := make([]string, len(nodes))
+	for i, n := range nodes {
+		options[i] = n.Title
+	}
+	fromSelect := widget.NewSelect(options, nil)
+	toSelect := widget.NewSelect(options, nil)
+	typesGroup := widget.NewCheckGroup(registry.RelationTypeNames(), nil)
+	depthEntry := widget.NewEntry()
+	depthEntry.SetPlaceHolder(strconv.Itoa(domain.DefaultMaxPathDepth))
+	allCheck := widget.NewCheck("", nil)
+	weightedCheck := widget.NewCheck("", nil)
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("From", fromSelect),
+		widget.NewFormItem("To", toSelect),
+		widget.NewFormItem("Relationship Types (none for all)", typesGroup),
+		widget.NewFormItem("Max Depth", depthEntry),
+		widget.NewFormItem("All Shortest Paths", allCheck),
+		widget.NewFormItem("Lowest Total Weight", weightedCheck),
+	}
+	dialog.ShowForm("Find Paths", "Find", "Cancel", formItems, func(valid bool) {
+		fromIdx, toIdx := indexOf(options, fromSelect.Selected), indexOf(options, toSelect.Selected)
+		if !valid || fromIdx < 0 || toIdx < 0 {
+			return
+		}
+		opts := domain.PathOptions{All: allCheck.Checked, Weighted: weightedCheck.Checked, At: asOf}
+		for _, name := range typesGroup.Selected {
+			opts.RelationTypes = append(opts.RelationTypes, domain.RelationType(name))
+		}
+		if text := strings.TrimSpace(depthEntry.Text); text != "" {
+			depth, err := strconv.Atoi(text)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("max depth must be a whole number"), w)
+				return
+			}
+			opts.MaxDepth = depth
+		}
+		paths, err := useCase.FindPaths(context.Background(), nodes[fromIdx].ID, nodes[toIdx].ID, opts)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		onFound(paths)
+		if opts.Weighted {
+			dialog.ShowInformation("Find Paths", "Total weight: "+strconv.FormatFloat(paths[0].Cost, 'f', -1, 64), w)
+		}
+	}, w)
+}
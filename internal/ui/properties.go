@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// propertyForm shows one input per custom property of a node type, chosen by the
+// property kind. It is rebuilt whenever the node type changes.
+type propertyForm struct {
+	content *fyne.Container
+	values  map[string]func() string
+}
+
+func newPropertyForm() *propertyForm {
+	return &propertyForm{
+		content: container.NewVBox(),
+		values:  make(map[string]func() string),
+	}
+}
+
+// SetSchema replaces the inputs with those of defs, pre-filled from values.
+func (f *propertyForm) SetSchema(defs []domain.PropertyDef, values map[string]string) {
+	f.values = make(map[string]func() string, len(defs))
+	if len(defs) == 0 {
+		f.content.Objects = nil
+		f.content.Refresh()
+		return
+	}
+
+	form := widget.NewForm()
+	for _, def := range defs {
+		input, value := propertyInput(def, values[def.Name])
+		f.values[def.Name] = value
+		label := def.Name
+		if def.Required {
+			label += " *"
+		}
+		form.Append(label, input)
+	}
+	f.content.Objects = []fyne.CanvasObject{form}
+	f.content.Refresh()
+}
+
+// Values returns the entered values. Validation is left to the use case.
+func (f *propertyForm) Values() map[string]string {
+	values := make(map[string]string, len(f.values))
+	for name, value := range f.values {
+		if v := value(); v != "" {
+			values[name] = v
+		}
+	}
+	return values
+}
+
+// Helper function: input widget for a property and a getter of its text value.
+func propertyInput(def domain.PropertyDef, value string) (fyne.CanvasObject, func() string) {
+	switch def.Kind {
+	case domain.PropertyBool:
+		check := widget.NewCheck("", nil)
+		check.SetChecked(value == "true")
+		return check, func() string {
+			if check.Checked {
+				return "true"
+			}
+			return "false"
+		}
+	case domain.PropertyEnum:
+		options := def.Options
+		if !def.Required {
+			options = append([]string{""}, options...)
+		}
+		sel := widget.NewSelect(options, nil)
+		sel.SetSelected(value)
+		return sel, func() string { return sel.Selected }
+	default:
+		entry := widget.NewEntry()
+		entry.SetText(value)
+		switch def.Kind {
+		case domain.PropertyNumber:
+			entry.SetPlaceHolder("0")
+		case domain.PropertyDate:
+			entry.SetPlaceHolder(domain.PropertyDateLayout)
+		case domain.PropertyURL:
+			entry.SetPlaceHolder("https://")
+		}
+		return entry, func() string { return entry.Text }
+	}
+}
+
+// Helper function: format a property schema as one "name:kind[:required][:a|b]" line per property.
+func formatPropertyDefs(defs []domain.PropertyDef) string {
+	lines := make([]string, 0, len(defs))
+	for _, def := range defs {
+		parts := []string{def.Name, string(def.Kind)}
+		if def.Required {
+			parts = append(parts, "required")
+		}
+		if len(def.Options) > 0 {
+			parts = append(parts, strings.Join(def.Options, "|"))
+		}
+		lines = append(lines, strings.Join(parts, ":"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Helper function: parse the format written by formatPropertyDefs. Blank lines are skipped.
+func parsePropertyDefs(text string) ([]domain.PropertyDef, error) {
+	var defs []domain.PropertyDef
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("line %d: expected name:kind", i+1)
+		}
+		def := domain.PropertyDef{
+			Name: strings.TrimSpace(parts[0]),
+			Kind: domain.PropertyKind(strings.TrimSpace(parts[1])),
+		}
+		for _, part := range parts[2:] {
+			part = strings.TrimSpace(part)
+			if part == "required" {
+				def.Required = true
+				continue
+			}
+			for _, option := range strings.Split(part, "|") {
+				if option = strings.TrimSpace(option); option != "" {
+					def.Options = append(def.Options, option)
+				}
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// showReadingList asks for a node and lists it with its prerequisites in reading order.
+// onShow is called with the list if the user chooses to show it in the graph.
+func showReadingList(useCase *usecase.NodeUseCase, nodes []*domain.Node, w fyne.Window, onShow func([]*domain.Node)) {
+	options := make([]string, len(nodes))
+	for i, n := range nodes {
+		options[i] = n.Title
+	}
+	startSelect := widget.NewSelect(options, nil)
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Read up to", startSelect),
+	}
+	dialog.ShowForm("Reading List", "Show", "Cancel", formItems, func(valid bool) {
+		idx := indexOf(options, startSelect.Selected)
+		if !valid || idx < 0 {
+			return
+		}
+		list, err := useCase.ReadingList(context.Background(), nodes[idx].ID)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+
+		entries := widget.NewList(
+			func() int { return len(list) },
+			func() fyne.CanvasObject { return widget.NewLabel("") },
+			func(id widget.ListItemID, obj fyne.CanvasObject) {
+				obj.(*widget.Label).SetText(fmt.Sprintf("%d. %s (%s)", id+1, list[id].Title, list[id].Type))
+			},
+		)
+		scroll := container.NewVScroll(entries)
+		scroll.SetMinSize(fyne.NewSize(320, 240))
+		dialog.ShowCustomConfirm("Reading List", "Show in Graph", "Close", scroll, func(show bool) {
+			if show {
+				onShow(list)
+			}
+		}, w)
+	}, w)
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// relationshipAttributes holds the inputs for the optional weight, confidence and validity
+// period of a relationship.
+type relationshipAttributes struct {
+	weight     *widget.Entry
+	confidence *widget.Entry
+	validFrom  *widget.Entry
+	validTo    *widget.Entry
+}
+
+func newRelationshipAttributes(rel *domain.Relationship) *relationshipAttributes {
+	a := &relationshipAttributes{
+		weight:     widget.NewEntry(),
+		confidence: widget.NewEntry(),
+		validFrom:  widget.NewEntry(),
+		validTo:    widget.NewEntry(),
+	}
+	a.weight.SetPlaceHolder(strconv.FormatFloat(domain.DefaultWeight, 'f', -1, 64))
+	a.confidence.SetPlaceHolder("0..1")
+	a.validFrom.SetPlaceHolder(domain.PropertyDateLayout)
+	a.validTo.SetPlaceHolder(domain.PropertyDateLayout)
+	if rel != nil {
+		a.weight.SetText(formatOptionalFloat(rel.Weight))
+		a.confidence.SetText(formatOptionalFloat(rel.Confidence))
+		a.validFrom.SetText(formatOptionalDate(rel.ValidFrom))
+		a.validTo.SetText(formatOptionalDate(rel.ValidTo))
+	}
+	return a
+}
+
+// FormItems returns the inputs as form rows.
+func (a *relationshipAttributes) FormItems() []*widget.FormItem {
+	return []*widget.FormItem{
+		widget.NewFormItem("Weight", a.weight),
+		widget.NewFormItem("Confidence", a.confidence),
+		widget.NewFormItem("Valid From", a.validFrom),
+		widget.NewFormItem("Valid To", a.validTo),
+	}
+}
+
+// Apply parses the inputs into rel. Range checks are left to the use case.
+func (a *relationshipAttributes) Apply(rel *domain.Relationship) error {
+	var err error
+	if rel.Weight, err = parseOptionalFloat("weight", a.weight.Text); err != nil {
+		return err
+	}
+	if rel.Confidence, err = parseOptionalFloat("confidence", a.confidence.Text); err != nil {
+		return err
+	}
+	if rel.ValidFrom, err = parseOptionalDate("valid from", a.validFrom.Text); err != nil {
+		return err
+	}
+	rel.ValidTo, err = parseOptionalDate("valid to", a.validTo.Text)
+	return err
+}
+
+// EdgeWidget is the type label drawn at the middle of an edge. Tapping it opens an edit
+// dialog for the relationship.
+type EdgeWidget struct {
+	widget.BaseWidget
+	Edge         Edge
+	ParentWindow fyne.Window
+	UseCase      *usecase.NodeUseCase
+	Types        *domain.TypeRegistry
+	OnUpdate     func(*domain.Relationship)
+}
+
+// NewEdgeWidget creates a new EdgeWidget. onUpdate receives the relationship as stored
+// after a successful update.
+func NewEdgeWidget(e Edge, w fyne.Window, uc *usecase.NodeUseCase, types *domain.TypeRegistry, onUpdate func(*domain.Relationship)) *EdgeWidget {
+	ew := &EdgeWidget{
+		Edge:         e,
+		ParentWindow: w,
+		UseCase:      uc,
+		Types:        types,
+		OnUpdate:     onUpdate,
+	}
+	ew.ExtendBaseWidget(ew)
+	return ew
+}
+
+// CreateRenderer implements the widget.Renderer interface.
+func (ew *EdgeWidget) CreateRenderer() fyne.WidgetRenderer {
+	text := canvas.NewText(ew.Edge.Type, color.Black)
+	text.TextSize = 10
+	background := canvas.NewRectangle(color.RGBA{R: 255, G: 255, B: 255, A: 200})
+	return widget.NewSimpleRenderer(container.NewStack(background, container.NewPadded(text)))
+}
+
+// Tapped opens an edit dialog for the relationship.
+func (ew *EdgeWidget) Tapped(_ *fyne.PointEvent) {
+	if ew.Edge.ID == "" {
+		return
+	}
+	rel, err := ew.UseCase.GetRelationship(context.Background(), ew.Edge.ID)
+	if err != nil {
+		dialog.ShowError(err, ew.ParentWindow)
+		return
+	}
+
+	typeSelect := widget.NewSelect(ew.Types.RelationTypeNames(), nil)
+	typeSelect.SetSelected(string(rel.Type))
+	descEntry := widget.NewEntry()
+	descEntry.SetText(rel.Description)
+	attributes := newRelationshipAttributes(rel)
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Link", widget.NewLabel(fmt.Sprintf("%s -> %s", ew.Edge.From.Title, ew.Edge.To.Title))),
+		widget.NewFormItem("Relationship Type", typeSelect),
+		widget.NewFormItem("Description", descEntry),
+	}
+	formItems = append(formItems, attributes.FormItems()...)
+
+	dialog.ShowForm("Edit Relationship", "Update", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		rel.Type = domain.RelationType(typeSelect.Selected)
+		rel.Description = descEntry.Text
+		if err := attributes.Apply(rel); err != nil {
+			dialog.ShowError(err, ew.ParentWindow)
+			return
+		}
+		if err := ew.UseCase.UpdateRelationship(context.Background(), rel); err != nil {
+			dialog.ShowError(err, ew.ParentWindow)
+			return
+		}
+		if ew.OnUpdate != nil {
+			ew.OnUpdate(rel)
+		}
+	}, ew.ParentWindow)
+}
+
+func (ew *EdgeWidget) TappedSecondary(_ *fyne.PointEvent) {}
+
+// Helper function: keep the edges valid on the day of at, or all edges if at is nil.
+// Edges without a stored relationship are always kept.
+func edgesValidAt(edges []Edge, at *time.Time) []Edge {
+	if at == nil {
+		return edges
+	}
+	var result []Edge
+	for _, e := range edges {
+		if e.Relationship == nil || e.Relationship.ValidAt(*at) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Helper function: apply an updated relationship to the edges showing it.
+func updateEdges(edges []Edge, rel *domain.Relationship) {
+	for i := range edges {
+		if edges[i].ID == rel.ID {
+			edges[i].Type = string(rel.Type)
+			edges[i].Relationship = rel
+		}
+	}
+}
+
+// Helper function: parse an optional number, an empty text gives 0.
+func parseOptionalFloat(name, text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", name)
+	}
+	return f, nil
+}
+
+// Helper function: parse an optional date, an empty text gives nil.
+func parseOptionalDate(name, text string) (*time.Time, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(domain.PropertyDateLayout, text)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date like %s", name, domain.PropertyDateLayout)
+	}
+	return &t, nil
+}
+
+func formatOptionalFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatOptionalDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(domain.PropertyDateLayout)
+}
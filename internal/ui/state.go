@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// uiState holds what the graph window shows. Every UI action reads it through Snapshot
+// and changes it through Update, so that background operations can apply their results
+// while the UI reads it. Update must not be called from inside another Update.
+type uiState struct {
+	mu   sync.Mutex
+	data graphData
+}
+
+// graphData is the content of the graph window.
+type graphData struct {
+	// allNodes holds every node known to the window and shownNodes those on the canvas.
+	allNodes   []*domain.Node
+	shownNodes []*domain.Node
+	// allEdges holds every relationship known to the window and shownEdges those on the
+	// canvas. loadedEdges are those searches pick the edges between their results from.
+	allEdges    []Edge
+	shownEdges  []Edge
+	loadedEdges []Edge
+	// highlight holds the paths found by Find Paths until the next reset.
+	highlight *pathHighlight
+	// colors holds the colours chosen under Analytics, nil shows the type colours.
+	colors map[string]color.Color
+	// asOf hides the relationships that are not valid on that day, nil shows all of them.
+	asOf *time.Time
+}
+
+func newUIState(nodes []*domain.Node, edges []Edge) *uiState {
+	return &uiState{data: graphData{
+		allNodes:    nodes,
+		shownNodes:  nodes,
+		allEdges:    edges,
+		shownEdges:  edges,
+		loadedEdges: edges,
+	}}
+}
+
+// Snapshot returns a copy of the state that later updates do not change. The nodes are
+// shared.
+func (s *uiState) Snapshot() graphData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g := s.data
+	g.allNodes = append([]*domain.Node(nil), g.allNodes...)
+	g.shownNodes = append([]*domain.Node(nil), g.shownNodes...)
+	g.allEdges = append([]Edge(nil), g.allEdges...)
+	g.shownEdges = append([]Edge(nil), g.shownEdges...)
+	g.loadedEdges = append([]Edge(nil), g.loadedEdges...)
+	return g
+}
+
+// Update changes the state with change, which sees no other change meanwhile.
+func (s *uiState) Update(change func(g *graphData)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(&s.data)
+}
+
+// visibleEdges returns the shown edges valid on the asOf day.
+func (g *graphData) visibleEdges() []Edge {
+	return edgesValidAt(g.shownEdges, g.asOf)
+}
+
+// addNode adds a created node and shows every node.
+func (g *graphData) addNode(n *domain.Node) {
+	g.allNodes = append(g.allNodes, n)
+	g.shownNodes = g.allNodes
+}
+
+// removeNode removes a deleted node and its edges.
+func (g *graphData) removeNode(id string) {
+	g.allNodes = filterNodes(g.allNodes, func(n *domain.Node) bool { return n.ID != id })
+	g.shownNodes = filterNodes(g.shownNodes, func(n *domain.Node) bool { return n.ID != id })
+	g.allEdges = filterEdges(g.allEdges, id)
+	g.shownEdges = filterEdges(g.shownEdges, id)
+	g.loadedEdges = filterEdges(g.loadedEdges, id)
+}
+
+// removeEdges removes the edges of deleted relationships, those drop reports.
+func (g *graphData) removeEdges(drop func(Edge) bool) {
+	g.allEdges = removeEdges(g.allEdges, drop)
+	g.shownEdges = removeEdges(g.shownEdges, drop)
+	g.loadedEdges = removeEdges(g.loadedEdges, drop)
+}
+
+// replaceNode puts n in place of the nodes with the ID of n or one of the old IDs, and
+// points their edges at n. It adds n when no such node is loaded. The replaced nodes are
+// left unchanged, as snapshots may still read them.
+func (g *graphData) replaceNode(n *domain.Node, old ...string) {
+	replaced := func(id string) bool { return id == n.ID || indexOf(old, id) >= 0 }
+	replace := func(nodes []*domain.Node) ([]*domain.Node, bool) {
+		var result []*domain.Node
+		found := false
+		for _, node := range nodes {
+			switch {
+			case !replaced(node.ID):
+				result = append(result, node)
+			case !found:
+				found = true
+				result = append(result, n)
+			}
+		}
+		return result, found
+	}
+	var found bool
+	if g.allNodes, found = replace(g.allNodes); !found {
+		g.allNodes = append(g.allNodes, n)
+		g.shownNodes = append(g.shownNodes, n)
+	} else {
+		g.shownNodes, _ = replace(g.shownNodes)
+	}
+	for _, edges := range [][]Edge{g.allEdges, g.shownEdges, g.loadedEdges} {
+		for i := range edges {
+			if replaced(edges[i].From.ID) {
+				edges[i].From = n
+			}
+			if replaced(edges[i].To.ID) {
+				edges[i].To = n
+			}
+		}
+	}
+}
+
+// addEdges adds created relationships and shows them.
+func (g *graphData) addEdges(edges []Edge) {
+	g.allEdges = append(g.allEdges, edges...)
+	g.shownEdges = append(g.shownEdges, edges...)
+}
+
+// showResults shows the nodes of a search and the loaded edges between them.
+func (g *graphData) showResults(results []*domain.Node) {
+	g.shownNodes = results
+	var edges []Edge
+	for _, e := range g.loadedEdges {
+		if containsNode(results, e.From) && containsNode(results, e.To) {
+			edges = append(edges, e)
+		}
+	}
+	g.shownEdges = edges
+}
+
+// showAll shows every node and relationship again.
+func (g *graphData) showAll() {
+	g.shownNodes = g.allNodes
+	g.shownEdges = g.allEdges
+}
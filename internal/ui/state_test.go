@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+func TestUIState(t *testing.T) {
+	nodes, edges := testGraph(3)
+	state := newUIState(nodes, edges)
+
+	state.Update(func(g *graphData) { g.showResults(nodes[:2]) })
+	g := state.Snapshot()
+	assert.Equal(t, nodes[:2], g.shownNodes)
+	assert.Equal(t, edges[:1], g.shownEdges, "Only the edges between the results should be shown")
+
+	created := &domain.Node{ID: "n3", Title: "Created"}
+	state.Update(func(g *graphData) { g.addNode(created) })
+	g = state.Snapshot()
+	assert.Len(t, g.allNodes, 4)
+	assert.Equal(t, g.allNodes, g.shownNodes, "A created node should show every node")
+
+	state.Update(func(g *graphData) { g.removeNode("n1") })
+	g = state.Snapshot()
+	assert.Nil(t, findNodeByID(g.allNodes, "n1"), "A deleted node should be removed")
+	assert.Empty(t, g.shownEdges, "The edges of a deleted node should be hidden")
+
+	state.Update(func(g *graphData) { g.showAll() })
+	g = state.Snapshot()
+	assert.Empty(t, g.shownEdges, "The edges of a deleted node should stay gone after a reset")
+
+	restored := Edge{ID: "e3", From: nodes[0], To: nodes[2]}
+	state.Update(func(g *graphData) { g.addEdges([]Edge{restored}) })
+	state.Update(func(g *graphData) { g.removeEdges(func(e Edge) bool { return e.ID == restored.ID }) })
+	state.Update(func(g *graphData) { g.showAll() })
+	assert.NotContains(t, state.Snapshot().shownEdges, restored, "A deleted edge should stay gone after a reset")
+
+	g.shownNodes[0] = nil
+	assert.NotNil(t, state.Snapshot().shownNodes[0], "A snapshot should not share its lists with the state")
+}
+
+// TestUIStateReplaceNode merges a node into another, as the duplicates dialog does.
+func TestUIStateReplaceNode(t *testing.T) {
+	nodes, edges := testGraph(3)
+	state := newUIState(nodes, edges)
+	before := state.Snapshot()
+
+	survivor := &domain.Node{ID: "n0", Title: "Merged"}
+	state.Update(func(g *graphData) { g.replaceNode(survivor, "n1") })
+	g := state.Snapshot()
+	assert.Equal(t, []*domain.Node{survivor, nodes[2]}, g.allNodes, "The survivor should take the place of both nodes")
+	assert.Equal(t, g.allNodes, g.shownNodes)
+	for _, e := range g.allEdges {
+		assert.NotEqual(t, "n1", e.From.ID, "No edge should point at the merged node")
+		assert.NotEqual(t, "n1", e.To.ID, "No edge should point at the merged node")
+	}
+	assert.Same(t, survivor, g.loadedEdges[1].From, "The edges should point at the survivor")
+
+	assert.Equal(t, "Node 0", before.allNodes[0].Title, "A snapshot should keep the nodes it read")
+	assert.Same(t, nodes[1], before.allEdges[0].To, "A snapshot should keep the edges it read")
+}
+
+// TestUIStateConcurrent changes the state from several goroutines, as background operations
+// do, while another reads it. Run with -race.
+func TestUIStateConcurrent(t *testing.T) {
+	state := newUIState(nil, nil)
+	const writers, adds = 4, 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				node := &domain.Node{ID: fmt.Sprintf("w%d-%d", w, i)}
+				state.Update(func(g *graphData) { g.addNode(node) })
+				state.Update(func(g *graphData) {
+					g.addEdges([]Edge{{ID: node.ID, From: node, To: node}})
+				})
+			}
+		}(w)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < writers*adds; i++ {
+			g := state.Snapshot()
+			assert.LessOrEqual(t, len(g.shownEdges), len(g.allNodes))
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	g := state.Snapshot()
+	assert.Len(t, g.allNodes, writers*adds, "No created node should be lost")
+	assert.Len(t, g.shownEdges, writers*adds, "No created edge should be lost")
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+const (
+	// maxGrowth limits how much larger than others a node with many links is drawn.
+	maxGrowth = 2.5
+	// dashLength and dotLength are the drawn part of a dashed and a dotted line, both
+	// followed by a gap of dashGap, before zooming.
+	dashLength = 10
+	dotLength  = 2
+	dashGap    = 6
+)
+
+// graphStyle is the visual encoding of the graph: the theme of the configuration and the
+// switches of the legend. The switches may be toggled while the graph is drawn in the
+// background.
+type graphStyle struct {
+	theme        domain.GraphTheme
+	edgeLabels   atomic.Bool
+	sizeByDegree atomic.Bool
+}
+
+func newGraphStyle(theme domain.GraphTheme) *graphStyle {
+	s := &graphStyle{theme: theme}
+	s.edgeLabels.Store(!theme.HideEdgeLabels)
+	s.sizeByDegree.Store(theme.SizeByDegree)
+	return s
+}
+
+// growth returns how much larger each node is drawn: 1 for every node unless nodes are
+// sized by degree, then growing with the logarithm of the shown links.
+func (s *graphStyle) growth(nodes []*domain.Node, edges []Edge) map[string]float32 {
+	growth := make(map[string]float32, len(nodes))
+	degrees := make(map[string]int, len(nodes))
+	if s.sizeByDegree.Load() {
+		for _, e := range edges {
+			degrees[e.From.ID]++
+			degrees[e.To.ID]++
+		}
+	}
+	for _, n := range nodes {
+		growth[n.ID] = float32(math.Min(1+0.3*math.Log2(1+float64(degrees[n.ID])), maxGrowth))
+	}
+	return growth
+}
+
+// nodeShape returns the named shape filled with fill, size wide and high.
+func nodeShape(shape string, fill color.Color, size float32) fyne.CanvasObject {
+	var obj fyne.CanvasObject
+	switch shape {
+	case domain.ShapeSquare, domain.ShapeRounded:
+		rect := canvas.NewRectangle(fill)
+		rect.StrokeWidth = 2
+		rect.StrokeColor = color.White
+		if shape == domain.ShapeRounded {
+			rect.CornerRadius = size / 4
+		}
+		obj = rect
+	case domain.ShapeRing:
+		circle := canvas.NewCircle(color.Transparent)
+		circle.StrokeWidth = fyne.Max(size/6, 2)
+		circle.StrokeColor = fill
+		obj = circle
+	default:
+		circle := canvas.NewCircle(fill)
+		circle.StrokeWidth = 2
+		circle.StrokeColor = color.White
+		obj = circle
+	}
+	obj.Resize(fyne.NewSize(size, size))
+	return obj
+}
+
+// highlightShape draws a highlight border on a shape made by nodeShape.
+func highlightShape(obj fyne.CanvasObject) {
+	switch shape := obj.(type) {
+	case *canvas.Circle:
+		shape.StrokeWidth = 4
+		shape.StrokeColor = highlightColor
+	case *canvas.Rectangle:
+		shape.StrokeWidth = 4
+		shape.StrokeColor = highlightColor
+	}
+}
+
+// styledLine draws a line from one point to another in the colour, width and line style
+// of style. Dashes and dots are separate lines, scaled by zoom.
+func styledLine(from, to fyne.Position, style domain.EdgeStyle, zoom float32) []fyne.CanvasObject {
+	stroke := parseHexColor(style.Color, color.Black)
+	width := fyne.Max(float32(style.Width)*fyne.Min(zoom, 1), 1)
+	segment := func(a, b fyne.Position) fyne.CanvasObject {
+		line := canvas.NewLine(stroke)
+		line.StrokeWidth = width
+		line.Position1 = a
+		line.Position2 = b
+		return line
+	}
+
+	var drawn float32
+	switch style.Line {
+	case domain.LineDashed:
+		drawn = dashLength * zoom
+	case domain.LineDotted:
+		drawn = dotLength * zoom
+	default:
+		return []fyne.CanvasObject{segment(from, to)}
+	}
+	gap := dashGap * zoom
+	dx, dy := to.X-from.X, to.Y-from.Y
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 || drawn+gap <= 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+	var lines []fyne.CanvasObject
+	for start := float32(0); start < length; start += drawn + gap {
+		end := fyne.Min(start+drawn, length)
+		lines = append(lines, segment(
+			fyne.NewPos(from.X+ux*start, from.Y+uy*start),
+			fyne.NewPos(from.X+ux*end, from.Y+uy*end),
+		))
+	}
+	return lines
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// suggestionLimit is the number of suggested links shown in the detail panel.
+const suggestionLimit = 5
+
+// suggestedLinks lists the suggestions loaded for node, or err if they could not be loaded,
+// each with a button that creates a RELATED_TO relationship to it and passes it to onLink.
+func suggestedLinks(useCase *usecase.NodeUseCase, node *domain.Node, suggestions []analytics.Suggestion, err error, w fyne.Window,
+	onLink func(*domain.Relationship)) fyne.CanvasObject {
+	box := container.NewVBox(widget.NewLabelWithStyle("Suggested links", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	if err != nil {
+		box.Add(widget.NewLabel("Suggestions unavailable: " + err.Error()))
+		return box
+	}
+	if len(suggestions) == 0 {
+		box.Add(widget.NewLabel("No suggestions"))
+		return box
+	}
+	for _, s := range suggestions {
+		target := s.Node
+		var linkBtn *widget.Button
+		linkBtn = widget.NewButton("Link", func() {
+			rel := &domain.Relationship{SourceID: node.ID, TargetIDs: []string{target.ID}, Type: domain.RelatedTo}
+			ids, err := useCase.CreateRelationship(context.Background(), rel)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			rel.ID = ids[0]
+			linkBtn.SetText("Linked")
+			linkBtn.Disable()
+			if onLink != nil {
+				onLink(rel)
+			}
+		})
+		label := widget.NewLabel(fmt.Sprintf("%s (%s)", target.Title, suggestionReasons(s)))
+		box.Add(container.NewBorder(nil, nil, nil, linkBtn, label))
+	}
+	return box
+}
+
+// Helper function: describe the signals behind a suggestion.
+func suggestionReasons(s analytics.Suggestion) string {
+	var reasons []string
+	if s.Similarity > 0 {
+		reasons = append(reasons, fmt.Sprintf("%.0f%% similar", s.Similarity*100))
+	}
+	if len(s.SharedTags) > 0 {
+		reasons = append(reasons, "tags "+strings.Join(s.SharedTags, ", "))
+	}
+	if s.CommonNeighbours > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d common neighbours", s.CommonNeighbours))
+	}
+	return strings.Join(reasons, "; ")
+}
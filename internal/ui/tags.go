@@ -0,0 +1,366 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+const (
+	tagsPanelWidth  = 220
+	minTagTextSize  = 12
+	maxTagTextSize  = 26
+	tagCloudSpacing = 6
+)
+
+// tagsPanel shows the tags of the workspace either as a cloud sized by usage or as a
+// collapsible tree of hierarchical tags. Tapping a tag filters the graph to the nodes
+// carrying it or one of its descendants, the secondary tap offers rename and merge.
+type tagsPanel struct {
+	tags     *usecase.TagUseCase
+	window   fyne.Window
+	cloud    *fyne.Container
+	tree     *widget.Tree
+	content  fyne.CanvasObject
+	onSelect func(name string)
+	onRename func(oldName, newName string)
+	onRetag  func(sources []string, target string)
+
+	// byName and children back the tree; children maps a parent to its child tags, "" is the root.
+	byName   map[string]domain.Tag
+	children map[string][]string
+}
+
+// newTagsPanel creates the panel. onSelect is called with the tapped tag, onRename and
+// onRetag after tags were renamed or merged so cached nodes can be updated.
+func newTagsPanel(tags *usecase.TagUseCase, w fyne.Window, onSelect func(string), onRename func(string, string), onRetag func([]string, string)) *tagsPanel {
+	p := &tagsPanel{
+		tags:     tags,
+		window:   w,
+		cloud:    container.New(&flowLayout{width: tagsPanelWidth, spacing: tagCloudSpacing}),
+		onSelect: onSelect,
+		onRename: onRename,
+		onRetag:  onRetag,
+	}
+
+	p.tree = widget.NewTree(
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			return p.children[uid]
+		},
+		func(uid widget.TreeNodeID) bool {
+			return uid == "" || len(p.children[uid]) > 0
+		},
+		func(_ bool) fyne.CanvasObject {
+			return newTagChip(domain.Tag{}, "", minTagTextSize, p.onSelect, p.showMenu)
+		},
+		func(uid widget.TreeNodeID, _ bool, obj fyne.CanvasObject) {
+			tag := p.byName[uid]
+			label := fmt.Sprintf("%s (%d)", uid[len(tag.Parent):], tag.Count)
+			if tag.Parent != "" {
+				label = strings.TrimPrefix(label, domain.TagSeparator)
+			}
+			obj.(*tagChip).SetTag(tag, label)
+		},
+	)
+
+	cloudScroll := container.NewVScroll(p.cloud)
+	cloudScroll.SetMinSize(fyne.NewSize(tagsPanelWidth, 0))
+	p.tree.Hide()
+
+	modeRadio := widget.NewRadioGroup([]string{"Cloud", "Tree"}, func(mode string) {
+		if mode == "Tree" {
+			cloudScroll.Hide()
+			p.tree.Show()
+		} else {
+			p.tree.Hide()
+			cloudScroll.Show()
+		}
+	})
+	modeRadio.Horizontal = true
+	modeRadio.Required = true
+	modeRadio.SetSelected("Cloud")
+
+	refreshButton := widget.NewButton("Refresh", p.Reload)
+	cleanupButton := widget.NewButton("Clean Up", p.deleteOrphans)
+	header := container.NewVBox(
+		widget.NewLabelWithStyle("Tags", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		modeRadio,
+		container.NewGridWithColumns(2, refreshButton, cleanupButton),
+	)
+	p.content = container.NewBorder(header, nil, nil, nil, container.NewStack(cloudScroll, p.tree))
+
+	p.Reload()
+	return p
+}
+
+// SetUseCase points the panel at another workspace and reloads it.
+func (p *tagsPanel) SetUseCase(tags *usecase.TagUseCase) {
+	p.tags = tags
+	p.Reload()
+}
+
+// Reload fetches the tags and rebuilds the cloud.
+func (p *tagsPanel) Reload() {
+	tags, err := p.tags.ListTags(context.Background())
+	if err != nil {
+		dialog.ShowError(err, p.window)
+		return
+	}
+
+	minCount, maxCount := 0, 0
+	for i, tag := range tags {
+		if i == 0 || tag.Count < minCount {
+			minCount = tag.Count
+		}
+		if tag.Count > maxCount {
+			maxCount = tag.Count
+		}
+	}
+
+	objects := make([]fyne.CanvasObject, 0, len(tags))
+	p.byName = make(map[string]domain.Tag, len(tags))
+	p.children = make(map[string][]string)
+	for _, tag := range tags {
+		label := fmt.Sprintf("%s (%d)", tag.Name, tag.Count)
+		objects = append(objects, newTagChip(tag, label, tagTextSize(tag.Count, minCount, maxCount), p.onSelect, p.showMenu))
+		p.byName[tag.Name] = tag
+		p.children[tag.Parent] = append(p.children[tag.Parent], tag.Name)
+	}
+	p.cloud.Objects = objects
+	p.cloud.Refresh()
+	p.tree.Refresh()
+}
+
+func (p *tagsPanel) showMenu(tag domain.Tag, pos fyne.Position) {
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("Show Nodes", func() { p.onSelect(tag.Name) }),
+		fyne.NewMenuItem("Rename...", func() { p.rename(tag) }),
+		fyne.NewMenuItem("Merge Into...", func() { p.merge(tag) }),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, p.window.Canvas(), pos)
+}
+
+func (p *tagsPanel) rename(tag domain.Tag) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(tag.Name)
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("New Name", nameEntry),
+	}
+	dialog.ShowForm("Rename Tag", "Rename", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		newName := strings.TrimSpace(nameEntry.Text)
+		if err := p.tags.RenameTag(context.Background(), tag.Name, newName); err != nil {
+			dialog.ShowError(err, p.window)
+			return
+		}
+		p.onRename(tag.Name, domain.NormalizeTag(newName))
+		p.Reload()
+	}, p.window)
+}
+
+func (p *tagsPanel) merge(tag domain.Tag) {
+	tags, err := p.tags.ListTags(context.Background())
+	if err != nil {
+		dialog.ShowError(err, p.window)
+		return
+	}
+	var targets []string
+	for _, t := range tags {
+		if t.Name != tag.Name {
+			targets = append(targets, t.Name)
+		}
+	}
+	if len(targets) == 0 {
+		dialog.ShowInformation("Merge Tag", "There is no other tag to merge into", p.window)
+		return
+	}
+
+	targetSelect := widget.NewSelect(targets, nil)
+	targetSelect.SetSelected(targets[0])
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Merge Into", targetSelect),
+	}
+	dialog.ShowForm(fmt.Sprintf("Merge %q", tag.Name), "Merge", "Cancel", formItems, func(valid bool) {
+		if !valid || targetSelect.Selected == "" {
+			return
+		}
+		if err := p.tags.MergeTags(context.Background(), []string{tag.Name}, targetSelect.Selected); err != nil {
+			dialog.ShowError(err, p.window)
+			return
+		}
+		p.onRetag([]string{tag.Name}, targetSelect.Selected)
+		p.Reload()
+	}, p.window)
+}
+
+func (p *tagsPanel) deleteOrphans() {
+	deleted, err := p.tags.DeleteOrphanTags(context.Background())
+	if err != nil {
+		dialog.ShowError(err, p.window)
+		return
+	}
+	message := "No unused tags found"
+	if len(deleted) > 0 {
+		message = "Deleted unused tags: " + strings.Join(deleted, ", ")
+	}
+	dialog.ShowInformation("Clean Up Tags", message, p.window)
+	p.Reload()
+}
+
+// tagChip is a tappable tag label of the tag cloud and the tag tree.
+type tagChip struct {
+	widget.BaseWidget
+	tag         domain.Tag
+	label       string
+	textSize    float32
+	text        *canvas.Text
+	onTap       func(string)
+	onSecondary func(domain.Tag, fyne.Position)
+}
+
+func newTagChip(tag domain.Tag, label string, textSize float32, onTap func(string), onSecondary func(domain.Tag, fyne.Position)) *tagChip {
+	c := &tagChip{
+		tag:         tag,
+		label:       label,
+		textSize:    textSize,
+		onTap:       onTap,
+		onSecondary: onSecondary,
+	}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetTag replaces the shown tag, used when tree rows are reused.
+func (c *tagChip) SetTag(tag domain.Tag, label string) {
+	c.tag = tag
+	c.label = label
+	c.Refresh()
+}
+
+// CreateRenderer implements the widget.Renderer interface.
+func (c *tagChip) CreateRenderer() fyne.WidgetRenderer {
+	c.text = canvas.NewText("", theme.Color(theme.ColorNamePrimary))
+	c.updateText()
+	return widget.NewSimpleRenderer(c.text)
+}
+
+func (c *tagChip) Refresh() {
+	if c.text != nil {
+		c.updateText()
+	}
+	c.BaseWidget.Refresh()
+}
+
+// updateText copies the chip state to the text object. Unused tags are greyed out.
+func (c *tagChip) updateText() {
+	c.text.Text = c.label
+	c.text.TextSize = c.textSize
+	c.text.Color = theme.Color(theme.ColorNamePrimary)
+	if c.tag.Count == 0 {
+		c.text.Color = theme.Color(theme.ColorNameDisabled)
+	}
+}
+
+func (c *tagChip) Tapped(_ *fyne.PointEvent) {
+	if c.onTap != nil && c.tag.Name != "" {
+		c.onTap(c.tag.Name)
+	}
+}
+
+func (c *tagChip) TappedSecondary(e *fyne.PointEvent) {
+	if c.onSecondary != nil && c.tag.Name != "" {
+		c.onSecondary(c.tag, e.AbsolutePosition)
+	}
+}
+
+// flowLayout places objects left to right and wraps them into rows of the given width.
+type flowLayout struct {
+	width   float32
+	spacing float32
+}
+
+func (l *flowLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	l.place(objects, size.Width, true)
+}
+
+func (l *flowLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.place(objects, l.width, false)
+}
+
+// place computes the rows for the given width, moving the objects when apply is set.
+func (l *flowLayout) place(objects []fyne.CanvasObject, width float32, apply bool) fyne.Size {
+	var x, y, rowHeight, maxWidth float32
+	for _, obj := range objects {
+		if !obj.Visible() {
+			continue
+		}
+		min := obj.MinSize()
+		if x > 0 && x+min.Width > width {
+			x = 0
+			y += rowHeight + l.spacing
+			rowHeight = 0
+		}
+		if apply {
+			obj.Resize(min)
+			obj.Move(fyne.NewPos(x, y))
+		}
+		x += min.Width + l.spacing
+		if x > maxWidth {
+			maxWidth = x
+		}
+		if min.Height > rowHeight {
+			rowHeight = min.Height
+		}
+	}
+	return fyne.NewSize(maxWidth, y+rowHeight)
+}
+
+// Helper function: scale the tag text size linearly between the least and most used tag.
+func tagTextSize(count, minCount, maxCount int) float32 {
+	if maxCount == minCount {
+		return minTagTextSize
+	}
+	ratio := float32(count-minCount) / float32(maxCount-minCount)
+	return minTagTextSize + ratio*(maxTagTextSize-minTagTextSize)
+}
+
+// Helper function: move renamed tags and their descendants to the new name on the given nodes.
+func renameNodeTags(nodes []*domain.Node, oldName, newName string) {
+	for _, n := range nodes {
+		for i, tag := range n.Tags {
+			n.Tags[i] = domain.RebaseTag(tag, oldName, newName)
+		}
+	}
+}
+
+// Helper function: replace the source tags with target on the given nodes.
+func retagNodes(nodes []*domain.Node, sources []string, target string) {
+	for _, n := range nodes {
+		var changed bool
+		var tags []string
+		for _, tag := range n.Tags {
+			if indexOf(sources, tag) >= 0 {
+				changed = true
+				continue
+			}
+			if tag != target {
+				tags = append(tags, tag)
+			}
+		}
+		if changed {
+			n.Tags = append(tags, target)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// defaultNodeColor is used for nodes whose type has no valid colour.
+var defaultNodeColor = color.RGBA{R: 0, G: 0, B: 255, A: 255}
+
+// typeIconNames are the theme icons offered for node types.
+var typeIconNames = []fyne.ThemeIconName{
+	theme.IconNameInfo,
+	theme.IconNameDocument,
+	theme.IconNameFile,
+	theme.IconNameFolder,
+	theme.IconNameHome,
+	theme.IconNameComputer,
+	theme.IconNameStorage,
+	theme.IconNameAccount,
+	theme.IconNameSearch,
+	theme.IconNameQuestion,
+	theme.IconNameWarning,
+	theme.IconNameHistory,
+	theme.IconNameSettings,
+}
+
+// loadRegistry loads the type registry of the workspace. On error it reports the problem
+// and falls back to the built-in types, so the graph can still be shown.
+func loadRegistry(types *usecase.TypeUseCase, w fyne.Window) *domain.TypeRegistry {
+	registry, err := types.Registry(context.Background())
+	if err != nil {
+		dialog.ShowError(err, w)
+		return domain.NewTypeRegistry(nil, nil)
+	}
+	return registry
+}
+
+// showTypesDialog lists the node and relationship types and lets the user add, edit and
+// delete them. onChange is called with the reloaded registry after every change.
+func showTypesDialog(types *usecase.TypeUseCase, w fyne.Window, onChange func(*domain.TypeRegistry)) {
+	registry := loadRegistry(types, w)
+
+	var nodeList, relList *widget.List
+	selectedNode, selectedRel := -1, -1
+	reload := func() {
+		registry = loadRegistry(types, w)
+		nodeList.Refresh()
+		relList.Refresh()
+		onChange(registry)
+	}
+
+	nodeList = widget.NewList(
+		func() int { return len(registry.NodeTypes) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			def := registry.NodeTypes[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s %s", def.Name, def.Color))
+		},
+	)
+	nodeList.OnSelected = func(id widget.ListItemID) { selectedNode = id }
+
+	relList = widget.NewList(
+		func() int { return len(registry.RelationTypes) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(relationTypeLabel(registry.RelationTypes[id]))
+		},
+	)
+	relList.OnSelected = func(id widget.ListItemID) { selectedRel = id }
+
+	nodeButtons := container.NewGridWithColumns(3,
+		widget.NewButton("Add", func() {
+			showNodeTypeForm(types, domain.NodeTypeDef{Color: "#607D8B"}, true, w, reload)
+		}),
+		widget.NewButton("Edit", func() {
+			if selectedNode >= 0 && selectedNode < len(registry.NodeTypes) {
+				showNodeTypeForm(types, registry.NodeTypes[selectedNode], false, w, reload)
+			}
+		}),
+		widget.NewButton("Delete", func() {
+			if selectedNode < 0 || selectedNode >= len(registry.NodeTypes) {
+				return
+			}
+			if err := types.DeleteNodeType(context.Background(), registry.NodeTypes[selectedNode].Name); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			selectedNode = -1
+			nodeList.UnselectAll()
+			reload()
+		}),
+	)
+	relButtons := container.NewGridWithColumns(3,
+		widget.NewButton("Add", func() {
+			showRelationTypeForm(types, registry, domain.RelationTypeDef{Directed: true}, true, w, reload)
+		}),
+		widget.NewButton("Edit", func() {
+			if selectedRel >= 0 && selectedRel < len(registry.RelationTypes) {
+				showRelationTypeForm(types, registry, registry.RelationTypes[selectedRel], false, w, reload)
+			}
+		}),
+		widget.NewButton("Delete", func() {
+			if selectedRel < 0 || selectedRel >= len(registry.RelationTypes) {
+				return
+			}
+			if err := types.DeleteRelationType(context.Background(), registry.RelationTypes[selectedRel].Name); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			selectedRel = -1
+			relList.UnselectAll()
+			reload()
+		}),
+	)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Node Types", container.NewBorder(nil, nodeButtons, nil, nil, nodeList)),
+		container.NewTabItem("Relationship Types", container.NewBorder(nil, relButtons, nil, nil, relList)),
+	)
+	d := dialog.NewCustom("Types", "Close", tabs, w)
+	d.Resize(fyne.NewSize(420, 400))
+	d.Show()
+}
+
+func showNodeTypeForm(types *usecase.TypeUseCase, def domain.NodeTypeDef, isNew bool, w fyne.Window, onSaved func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(string(def.Name))
+	nameEntry.SetPlaceHolder("UPPER_CASE")
+	if !isNew {
+		nameEntry.Disable()
+	}
+	colorEntry := widget.NewEntry()
+	colorEntry.SetText(def.Color)
+	colorEntry.SetPlaceHolder("#RRGGBB")
+	iconOptions := []string{""}
+	for _, name := range typeIconNames {
+		iconOptions = append(iconOptions, string(name))
+	}
+	iconSelect := widget.NewSelect(iconOptions, nil)
+	iconSelect.SetSelected(def.Icon)
+	descEntry := widget.NewEntry()
+	descEntry.SetText(def.Description)
+	propertiesEntry := widget.NewMultiLineEntry()
+	propertiesEntry.SetText(formatPropertyDefs(def.Properties))
+	propertiesEntry.SetPlaceHolder("status:enum:required:todo|doing|done\ndue:date")
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Colour", colorEntry),
+		widget.NewFormItem("Icon", iconSelect),
+		widget.NewFormItem("Description", descEntry),
+		widget.NewFormItem("Properties (name:kind[:required][:a|b])", propertiesEntry),
+	}
+	dialog.ShowForm("Node Type", "Save", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		propertyDefs, err := parsePropertyDefs(propertiesEntry.Text)
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		saved := domain.NodeTypeDef{
+			Name:        domain.NodeType(strings.TrimSpace(nameEntry.Text)),
+			Color:       strings.TrimSpace(colorEntry.Text),
+			Icon:        iconSelect.Selected,
+			Description: descEntry.Text,
+			Properties:  propertyDefs,
+		}
+		if err := types.SaveNodeType(context.Background(), saved); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		onSaved()
+	}, w)
+}
+
+func showRelationTypeForm(types *usecase.TypeUseCase, registry *domain.TypeRegistry, def domain.RelationTypeDef, isNew bool, w fyne.Window, onSaved func()) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(string(def.Name))
+	nameEntry.SetPlaceHolder("UPPER_CASE")
+	if !isNew {
+		nameEntry.Disable()
+	}
+	inverseSelect := widget.NewSelect(append([]string{""}, registry.RelationTypeNames()...), nil)
+	inverseSelect.SetSelected(string(def.Inverse))
+	directedCheck := widget.NewCheck("", nil)
+	directedCheck.SetChecked(def.Directed)
+	descEntry := widget.NewEntry()
+	descEntry.SetText(def.Description)
+	acyclicCheck := widget.NewCheck("", nil)
+	acyclicCheck.SetChecked(def.Rules.Acyclic)
+	noSelfLoopsCheck := widget.NewCheck("", nil)
+	noSelfLoopsCheck.SetChecked(def.Rules.NoSelfLoops)
+	maxOutDegreeEntry := widget.NewEntry()
+	maxOutDegreeEntry.SetPlaceHolder("unlimited")
+	if def.Rules.MaxOutDegree > 0 {
+		maxOutDegreeEntry.SetText(strconv.Itoa(def.Rules.MaxOutDegree))
+	}
+	sourceTypesEntry := widget.NewEntry()
+	sourceTypesEntry.SetText(joinNodeTypes(def.Rules.SourceTypes))
+	sourceTypesEntry.SetPlaceHolder("any")
+	targetTypesEntry := widget.NewEntry()
+	targetTypesEntry.SetText(joinNodeTypes(def.Rules.TargetTypes))
+	targetTypesEntry.SetPlaceHolder("any")
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Directed", directedCheck),
+		widget.NewFormItem("Inverse", inverseSelect),
+		widget.NewFormItem("Description", descEntry),
+		widget.NewFormItem("Acyclic", acyclicCheck),
+		widget.NewFormItem("No Self-Loops", noSelfLoopsCheck),
+		widget.NewFormItem("Max Out-Degree", maxOutDegreeEntry),
+		widget.NewFormItem("Source Types (comma separated)", sourceTypesEntry),
+		widget.NewFormItem("Target Types (comma separated)", targetTypesEntry),
+	}
+	dialog.ShowForm("Relationship Type", "Save", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		maxOutDegree := 0
+		if text := strings.TrimSpace(maxOutDegreeEntry.Text); text != "" {
+			var err error
+			if maxOutDegree, err = strconv.Atoi(text); err != nil {
+				dialog.ShowError(fmt.Errorf("max out-degree must be a whole number"), w)
+				return
+			}
+		}
+		saved := domain.RelationTypeDef{
+			Name:        domain.RelationType(strings.TrimSpace(nameEntry.Text)),
+			Inverse:     domain.RelationType(inverseSelect.Selected),
+			Directed:    directedCheck.Checked,
+			Description: descEntry.Text,
+			Rules: domain.RelationRules{
+				Acyclic:      acyclicCheck.Checked,
+				NoSelfLoops:  noSelfLoopsCheck.Checked,
+				MaxOutDegree: maxOutDegree,
+				SourceTypes:  splitNodeTypes(sourceTypesEntry.Text),
+				TargetTypes:  splitNodeTypes(targetTypesEntry.Text),
+			},
+		}
+		if err := types.SaveRelationType(context.Background(), saved); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		onSaved()
+	}, w)
+}
+
+// Helper function: describe a relationship type in the types list.
+func relationTypeLabel(def domain.RelationTypeDef) string {
+	label := string(def.Name)
+	if !def.Directed {
+		return label + " (undirected)"
+	}
+	if def.Inverse != "" {
+		label += " <-> " + string(def.Inverse)
+	}
+	if !def.Rules.IsZero() {
+		label += " (rules)"
+	}
+	return label
+}
+
+// Helper function: format node types as a comma separated list.
+func joinNodeTypes(types []domain.NodeType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Helper function: parse a comma separated list of node types, skipping blanks.
+func splitNodeTypes(text string) []domain.NodeType {
+	var types []domain.NodeType
+	for _, name := range strings.Split(text, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			types = append(types, domain.NodeType(name))
+		}
+	}
+	return types
+}
+
+// Helper function: parse a "#RRGGBB" colour, returning fallback for invalid values.
+func parseHexColor(s string, fallback color.Color) color.Color {
+	var r, g, b uint8
+	if len(s) != 7 || s[0] != '#' {
+		return fallback
+	}
+	if _, err := fmt.Sscanf(s[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return fallback
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+// Helper function: look up a theme icon by name, nil for unknown or empty names.
+func typeIcon(name string) fyne.Resource {
+	if name == "" {
+		return nil
+	}
+	return theme.Icon(fyne.ThemeIconName(name))
+}
+
+// Helper function: two short lines forming an arrowhead at to, pulled back by inset
+// so that it ends on the border of the target node.
+func arrowHead(from, to fyne.Position, inset float32, stroke color.Color) []fyne.CanvasObject {
+	dx, dy := float64(to.X-from.X), float64(to.Y-from.Y)
+	length := math.Hypot(dx, dy)
+	if length <= float64(inset) {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+	tip := fyne.NewPos(to.X-float32(ux)*inset, to.Y-float32(uy)*inset)
+
+	const size, spread = 10.0, 0.5
+	var lines []fyne.CanvasObject
+	for _, angle := range []float64{spread, -spread} {
+		sin, cos := math.Sincos(angle)
+		bx := ux*cos - uy*sin
+		by := ux*sin + uy*cos
+		line := canvas.NewLine(stroke)
+		line.StrokeWidth = 2
+		line.Position1 = tip
+		line.Position2 = fyne.NewPos(tip.X-float32(bx*size), tip.Y-float32(by*size))
+		lines = append(lines, line)
+	}
+	return lines
+}
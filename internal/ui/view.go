@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// viewsMenu is the Views menu of the main menu. It saves the shown arrangement under a
+// name, lists the saved views of the workspace to reopen them and deletes them. Nodes
+// moved while a view is open are saved to that view.
+type viewsMenu struct {
+	menu    *fyne.Menu
+	views   *usecase.ViewUseCase
+	window  fyne.Window
+	current string
+	// arrangement returns the shown search, layout, positions and zoom to save.
+	arrangement func() domain.View
+	onOpen      func(*domain.View)
+}
+
+// newViewsMenu creates the menu. arrangement is called when saving a view, onOpen with
+// the view the user opened.
+func newViewsMenu(views *usecase.ViewUseCase, w fyne.Window, arrangement func() domain.View, onOpen func(*domain.View)) *viewsMenu {
+	m := &viewsMenu{
+		menu:        fyne.NewMenu("Views"),
+		views:       views,
+		window:      w,
+		current:     domain.DefaultView,
+		arrangement: arrangement,
+		onOpen:      onOpen,
+	}
+	m.Reload()
+	return m
+}
+
+// SetUseCase switches to the views of another workspace and opens its default view.
+func (m *viewsMenu) SetUseCase(views *usecase.ViewUseCase) {
+	m.views = views
+	m.Open(domain.DefaultView)
+}
+
+// Current returns the name of the open view.
+func (m *viewsMenu) Current() string {
+	return m.current
+}
+
+// Open loads the named view and the saved views in the background and passes the view
+// to onOpen.
+func (m *viewsMenu) Open(name string) {
+	views := m.views
+	var view *domain.View
+	var saved []domain.View
+	runAsync(m.window, "Opening view", func(ctx context.Context) error {
+		var err error
+		if view, err = views.GetView(ctx, name); err != nil {
+			return err
+		}
+		saved, err = views.ListViews(ctx)
+		return err
+	}, func() {
+		m.current = view.Name
+		m.list(saved)
+		m.onOpen(view)
+	})
+}
+
+// Reload lists the saved views, with a check mark at the open one.
+func (m *viewsMenu) Reload() {
+	saved, err := m.views.ListViews(context.Background())
+	if err != nil {
+		dialog.ShowError(err, m.window)
+		return
+	}
+	m.list(saved)
+}
+
+// list shows saved in the menu.
+func (m *viewsMenu) list(saved []domain.View) {
+	defaultItem := fyne.NewMenuItem("Default View", func() { m.Open(domain.DefaultView) })
+	defaultItem.Checked = m.current == domain.DefaultView
+	items := []*fyne.MenuItem{
+		fyne.NewMenuItem("Save View As...", m.saveAs),
+		fyne.NewMenuItem("Delete View...", func() { m.deleteView(saved) }),
+		fyne.NewMenuItemSeparator(),
+		defaultItem,
+	}
+	for _, view := range saved {
+		name := view.Name
+		item := fyne.NewMenuItem(name, func() { m.Open(name) })
+		item.Checked = name == m.current
+		items = append(items, item)
+	}
+	m.menu.Items = items
+	m.menu.Refresh()
+}
+
+// saveAs asks for a name and saves the shown arrangement under it, replacing a view of
+// the same name after confirmation. The saved view becomes the open one.
+func (m *viewsMenu) saveAs() {
+	nameEntry := widget.NewEntry()
+	if m.current != domain.DefaultView {
+		nameEntry.SetText(m.current)
+	}
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("Name", nameEntry),
+	}
+	dialog.ShowForm("Save View", "Save", "Cancel", formItems, func(valid bool) {
+		if !valid {
+			return
+		}
+		view := m.arrangement()
+		view.Name = nameEntry.Text
+		save := func() {
+			if err := m.views.SaveView(context.Background(), view); err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+			stored, err := m.views.GetView(context.Background(), view.Name)
+			if err != nil {
+				dialog.ShowError(err, m.window)
+				return
+			}
+			m.current = stored.Name
+			m.Reload()
+		}
+		if _, err := m.views.GetView(context.Background(), view.Name); errors.Is(err, usecase.ErrViewNotFound) {
+			save()
+			return
+		}
+		dialog.ShowConfirm("Replace View", "Replace the saved view "+view.Name+"?", func(confirm bool) {
+			if confirm {
+				save()
+			}
+		}, m.window)
+	}, m.window)
+}
+
+// deleteView asks for a saved view and deletes it. Deleting the open view opens the
+// default view.
+func (m *viewsMenu) deleteView(saved []domain.View) {
+	if len(saved) == 0 {
+		dialog.ShowInformation("No views", "No saved views to delete", m.window)
+		return
+	}
+	names := make([]string, len(saved))
+	for i, view := range saved {
+		names[i] = view.Name
+	}
+	nameSelect := widget.NewSelect(names, nil)
+	nameSelect.SetSelected(names[0])
+	formItems := []*widget.FormItem{
+		widget.NewFormItem("View", nameSelect),
+	}
+	dialog.ShowForm("Delete View", "Delete", "Cancel", formItems, func(valid bool) {
+		if !valid || nameSelect.Selected == "" {
+			return
+		}
+		if err := m.views.DeleteView(context.Background(), nameSelect.Selected); err != nil {
+			dialog.ShowError(err, m.window)
+			return
+		}
+		if nameSelect.Selected == m.current {
+			m.Open(domain.DefaultView)
+			return
+		}
+		m.Reload()
+	}, m.window)
+}
@@ -0,0 +1,290 @@
+package ui
+
+import (
+	"image/color"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// graphModel is the view model of the graph canvas. Apply compares the nodes and edges to
+// show with those already on the canvas and only creates, moves or redraws what changed,
+// so that an edit in a large graph does not rebuild thousands of widgets.
+//
+// Edges of directed relationship types get an arrowhead at the target and every edge
+// gets a type label that opens the edit dialog of the relationship. The paths of highlight
+// are drawn over the edges between nodes that are shown. Tapping a node calls onSelect,
+// double-tapping it onExpand and dropping a dragged node onMoved.
+// Nodes listed in colors are filled with that colour instead of the colour of their type.
+// placement positions the nodes, keeps them in place across updates and scales the graph
+// by its zoom; edge labels are hidden below labelZoom. style sets the shapes, colours and
+// lines by type, whether edges are labelled and whether nodes grow with their links.
+//
+// The fields are read on every Apply, so that they can be changed in between. Show and
+// SetServices may be called from any goroutine.
+type graphModel struct {
+	mu           sync.Mutex
+	useCase      *usecase.NodeUseCase
+	types        *domain.TypeRegistry
+	window       fyne.Window
+	onEdgeUpdate func(*domain.Relationship)
+	onExpand     func(*domain.Node)
+	onMoved      func(*domain.Node, fyne.Position)
+	onSelect     func(*domain.Node)
+	colors       map[string]color.Color
+	highlight    *pathHighlight
+	placement    *graphLayout
+	style        *graphStyle
+
+	graph *fyne.Container
+	// size makes the whole layout area scrollable, including nodes moved beyond it.
+	size  *canvas.Rectangle
+	nodes map[string]*shownNode
+	edges map[string]*shownEdge
+}
+
+// shownNode is a node widget on the canvas and what it was drawn from.
+type shownNode struct {
+	widget *NodeWidget
+	look   nodeLook
+}
+
+// center returns the centre of the shape of the node on the canvas.
+func (s *shownNode) center() fyne.Position {
+	radius := s.widget.Size().Height / 2
+	return s.widget.Position().AddXY(radius, radius)
+}
+
+// nodeLook holds everything that changes how a node widget is drawn.
+type nodeLook struct {
+	title       string
+	icon        string
+	typeColor   string
+	style       domain.NodeStyle
+	fill        color.Color
+	highlighted bool
+	scale       float32
+	growth      float32
+}
+
+// shownEdge holds the lines and the label of an edge on the canvas and what they were
+// drawn from.
+type shownEdge struct {
+	lines []fyne.CanvasObject
+	label *EdgeWidget
+	look  edgeLook
+}
+
+// edgeLook holds everything that changes how an edge is drawn.
+type edgeLook struct {
+	from, to fyne.Position
+	relType  string
+	style    domain.EdgeStyle
+	directed bool
+	inset    float32
+	zoom     float32
+}
+
+func newGraphModel(placement *graphLayout, style *graphStyle) *graphModel {
+	return &graphModel{
+		placement: placement,
+		style:     style,
+		graph:     container.NewWithoutLayout(),
+		size:      canvas.NewRectangle(color.Transparent),
+		nodes:     make(map[string]*shownNode),
+		edges:     make(map[string]*shownEdge),
+	}
+}
+
+// SetServices draws the graph with the use case and the types of another workspace or
+// edited types from the next Show on.
+func (m *graphModel) SetServices(useCase *usecase.NodeUseCase, types *domain.TypeRegistry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.useCase, m.types = useCase, types
+}
+
+// Show updates the canvas to a snapshot of the UI state and passes it to view. The
+// updates are serialized with the reads of the model, which background operations may do.
+func (m *graphModel) Show(g graphData, view *zoomCanvas) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.colors, m.highlight = g.colors, g.highlight
+	graph := m.Apply(g.shownNodes, g.visibleEdges())
+	view.SetContent(graph, m.nodeCenters())
+}
+
+// NodeCenter returns the centre of the shape of a shown node on the canvas, false if the
+// node is not shown.
+func (m *graphModel) NodeCenter(id string) (fyne.Position, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	shown, ok := m.nodes[id]
+	if !ok {
+		return fyne.Position{}, false
+	}
+	return shown.center(), true
+}
+
+// nodeCenters returns the centres of the shapes of every shown node. The caller holds mu.
+func (m *graphModel) nodeCenters() []fyne.Position {
+	centers := make([]fyne.Position, 0, len(m.nodes))
+	for _, shown := range m.nodes {
+		centers = append(centers, shown.center())
+	}
+	return centers
+}
+
+// Apply updates the canvas to show nodes and edges and returns it. The returned
+// container is the same on every call.
+func (m *graphModel) Apply(nodes []*domain.Node, edges []Edge) *fyne.Container {
+	positions := m.placement.place(nodes, edges)
+	scale := m.placement.Zoom()
+	zoom := float32(scale)
+	growth := m.style.growth(nodes, edges)
+	// center returns the centre of the shape of a node.
+	center := func(id string) fyne.Position {
+		radius := nodeHeight / 2 * zoom * growth[id]
+		return positions[id].AddXY(radius, radius)
+	}
+	// Labels stick out to the right of the nodes.
+	width, height := layoutArea(len(nodes))
+	width, height = width*scale, height*scale
+	for id, pos := range positions {
+		size := nodeHeight * zoom * growth[id]
+		width = max(width, float64(pos.X+size))
+		height = max(height, float64(pos.Y+size))
+	}
+	m.size.SetMinSize(fyne.NewSize(float32(width)+nodeWidth*zoom, float32(height)))
+
+	objects := []fyne.CanvasObject{m.size}
+	// Edge labels are added last so that they stay tappable above the nodes.
+	var labels []fyne.CanvasObject
+	shownEdges := make(map[string]*shownEdge, len(edges))
+	for _, edge := range edges {
+		_, ok1 := positions[edge.From.ID]
+		_, ok2 := positions[edge.To.ID]
+		if !ok1 || !ok2 {
+			continue
+		}
+		key := edgeKey(edge)
+		if _, ok := shownEdges[key]; ok {
+			continue
+		}
+		def, known := m.types.RelationType(domain.RelationType(edge.Type))
+		look := edgeLook{
+			from:     center(edge.From.ID),
+			to:       center(edge.To.ID),
+			relType:  edge.Type,
+			style:    m.style.theme.EdgeStyle(domain.RelationType(edge.Type)),
+			directed: !known || def.Directed,
+			inset:    nodeHeight / 2 * zoom * growth[edge.To.ID],
+			zoom:     zoom,
+		}
+		shown := m.edges[key]
+		if shown == nil || shown.look != look {
+			if shown == nil {
+				shown = &shownEdge{}
+			}
+			if shown.label != nil && shown.look.relType != look.relType {
+				shown.label = nil
+			}
+			shown.look = look
+			shown.lines = styledLine(look.from, look.to, look.style, zoom)
+			if look.directed {
+				shown.lines = append(shown.lines, arrowHead(look.from, look.to, look.inset, parseHexColor(look.style.Color, color.Black))...)
+			}
+		}
+		shownEdges[key] = shown
+		objects = append(objects, shown.lines...)
+
+		if zoom < labelZoom || !m.style.edgeLabels.Load() {
+			continue
+		}
+		if shown.label == nil {
+			shown.label = NewEdgeWidget(edge, m.window, m.useCase, m.types, m.onEdgeUpdate)
+		}
+		label := shown.label
+		label.Edge, label.UseCase, label.Types, label.OnUpdate = edge, m.useCase, m.types, m.onEdgeUpdate
+		size := label.MinSize()
+		mid := fyne.NewPos((look.from.X+look.to.X)/2, (look.from.Y+look.to.Y)/2)
+		label.Move(mid.SubtractXY(size.Width/2, size.Height/2))
+		label.Resize(size)
+		labels = append(labels, label)
+	}
+	m.edges = shownEdges
+
+	// Highlighted paths are few and drawn again every time.
+	for _, link := range m.highlight.links() {
+		if _, ok1 := positions[link[0]]; ok1 {
+			if _, ok2 := positions[link[1]]; ok2 {
+				line := canvas.NewLine(highlightColor)
+				line.Position1 = center(link[0])
+				line.Position2 = center(link[1])
+				line.StrokeWidth = 5
+				objects = append(objects, line)
+			}
+		}
+	}
+
+	shownNodes := make(map[string]*shownNode, len(nodes))
+	for _, n := range nodes {
+		pos, ok := positions[n.ID]
+		if !ok {
+			continue
+		}
+		def, _ := m.types.NodeType(n.Type)
+		look := nodeLook{
+			title:       n.Title,
+			icon:        def.Icon,
+			typeColor:   def.Color,
+			style:       m.style.theme.NodeStyle(def),
+			fill:        m.colors[n.ID],
+			highlighted: m.highlight.hasNode(n.ID),
+			scale:       zoom,
+			growth:      growth[n.ID],
+		}
+		shown := m.nodes[n.ID]
+		if shown == nil {
+			shown = &shownNode{widget: NewNodeWidget(n, pos, m.types)}
+		}
+		nodeW := shown.widget
+		nodeW.Node, nodeW.Types = n, m.types
+		nodeW.OnExpand, nodeW.OnMoved, nodeW.OnSelect = m.onExpand, m.onMoved, m.onSelect
+		nodeW.Highlighted = look.highlighted
+		nodeW.Fill = look.fill
+		nodeW.Scale = look.scale
+		nodeW.Growth = look.growth
+		nodeW.Style = look.style
+		if shown.look != look {
+			shown.look = look
+			nodeW.Refresh()
+		}
+		size := nodeHeight * zoom * growth[n.ID]
+		nodeW.Pos = pos
+		nodeW.Move(pos)
+		nodeW.Resize(fyne.NewSize(size+(nodeWidth-nodeHeight)*zoom, size))
+		shownNodes[n.ID] = shown
+		objects = append(objects, nodeW)
+	}
+	m.nodes = shownNodes
+
+	// Refreshing the container would refresh every child; the changed ones are refreshed
+	// above.
+	m.graph.Objects = append(objects, labels...)
+	canvas.Refresh(m.graph)
+	return m.graph
+}
+
+// Helper function: the key of an edge on the canvas, its relationship id if known.
+func edgeKey(e Edge) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return e.From.ID + "|" + e.Type + "|" + e.To.ID
+}
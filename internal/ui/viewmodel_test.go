@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// benchmarkNodes is the size of the graphs of the benchmarks.
+const benchmarkNodes = 2000
+
+// testGraph returns a chain of n nodes, each linked to the next.
+func testGraph(n int) ([]*domain.Node, []Edge) {
+	nodes := make([]*domain.Node, n)
+	var edges []Edge
+	for i := range nodes {
+		nodes[i] = &domain.Node{ID: fmt.Sprintf("n%d", i), Title: fmt.Sprintf("Node %d", i), Type: domain.Concept}
+		if i > 0 {
+			edges = append(edges, Edge{ID: fmt.Sprintf("e%d", i), From: nodes[i-1], To: nodes[i], Type: string(domain.RelatedTo)})
+		}
+	}
+	return nodes, edges
+}
+
+func testModel(placement *graphLayout) *graphModel {
+	model := newGraphModel(placement, newGraphStyle(domain.DefaultGraphTheme()))
+	model.types = domain.NewTypeRegistry(nil, nil)
+	return model
+}
+
+// render creates the renderers of the widgets on the canvas, as drawing them would.
+func render(graph *fyne.Container) {
+	for _, obj := range graph.Objects {
+		if wid, ok := obj.(fyne.Widget); ok {
+			test.WidgetRenderer(wid)
+		}
+	}
+}
+
+func TestGraphModelApply(t *testing.T) {
+	test.NewTempApp(t)
+	nodes, edges := testGraph(3)
+	model := testModel(newGraphLayout())
+
+	graph := model.Apply(nodes, edges)
+	first := model.nodes["n0"].widget
+	var shownNodes, shownLabels int
+	for _, obj := range graph.Objects {
+		switch obj.(type) {
+		case *NodeWidget:
+			shownNodes++
+		case *EdgeWidget:
+			shownLabels++
+		}
+	}
+	assert.Equal(t, 3, shownNodes, "Every node should be shown")
+	assert.Equal(t, 2, shownLabels, "Every edge should be labelled")
+
+	nodes[0].Title = "Renamed"
+	assert.Same(t, graph, model.Apply(nodes, edges), "The canvas should be updated in place")
+	assert.Same(t, first, model.nodes["n0"].widget, "An updated node should keep its widget")
+	assert.Equal(t, "Renamed", model.nodes["n0"].look.title)
+
+	graph = model.Apply(nodes[1:], edges[1:])
+	assert.NotContains(t, model.nodes, "n0", "A removed node should be dropped")
+	assert.NotContains(t, model.edges, "e1", "The edges of a removed node should be dropped")
+	assert.NotContains(t, graph.Objects, fyne.CanvasObject(first))
+
+	graph = model.Apply(nodes, edges)
+	assert.NotSame(t, first, model.nodes["n0"].widget, "A node shown again should get a new widget")
+	assert.Contains(t, graph.Objects, fyne.CanvasObject(model.nodes["n0"].widget))
+}
+
+func TestGraphModelShow(t *testing.T) {
+	test.NewTempApp(t)
+	nodes, edges := testGraph(3)
+	placement := newGraphLayout()
+	model := testModel(placement)
+	view := newZoomCanvas(placement, func() {})
+
+	model.Show(graphData{shownNodes: nodes, shownEdges: edges}, view)
+	center, ok := model.NodeCenter("n0")
+	assert.True(t, ok, "A shown node should have a centre")
+	assert.Len(t, view.minimap.points, 3, "The minimap should show every node")
+	assert.Contains(t, view.minimap.points, center)
+
+	model.Show(graphData{shownNodes: nodes[1:], shownEdges: edges[1:]}, view)
+	view.pan(fyne.NewDelta(10, 10))
+	assert.Len(t, view.minimap.points, 2, "The minimap should drop a hidden node")
+}
+
+// BenchmarkRebuild draws the whole graph anew after one node was renamed, as the canvas
+// did before the view model.
+func BenchmarkRebuild(b *testing.B) {
+	test.NewTempApp(b)
+	nodes, edges := testGraph(benchmarkNodes)
+	placement := newGraphLayout()
+	placement.place(nodes, edges)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nodes[0].Title = fmt.Sprintf("Node %d", i)
+		render(testModel(placement).Apply(nodes, edges))
+	}
+}
+
+// BenchmarkApply updates the canvas of the graph after one node was renamed.
+func BenchmarkApply(b *testing.B) {
+	test.NewTempApp(b)
+	nodes, edges := testGraph(benchmarkNodes)
+	model := testModel(newGraphLayout())
+	render(model.Apply(nodes, edges))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		nodes[0].Title = fmt.Sprintf("Node %d", i)
+		render(model.Apply(nodes, edges))
+	}
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/app"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+// newWorkspaceSwitcher builds a select of the known workspaces and a button to open a new one.
+// onSwitch is called with the services of the newly opened workspace.
+func newWorkspaceSwitcher(useCase *usecase.NodeUseCase, workspaces app.Workspaces, w fyne.Window, onSwitch func(app.Services)) fyne.CanvasObject {
+	active := useCase.Workspace().Name
+	names := append([]string{active}, workspaces.Names...)
+	if stored, err := useCase.ListWorkspaces(context.Background()); err == nil {
+		names = append(names, stored...)
+	} else {
+		fyne.LogError("Failed to list workspaces", err)
+	}
+	names = uniqueSorted(names)
+
+	var workspaceSelect *widget.Select
+	open := func(name string) {
+		if name == active || workspaces.Switch == nil {
+			return
+		}
+		services, err := workspaces.Switch(name)
+		if err != nil {
+			dialog.ShowError(err, w)
+			workspaceSelect.SetSelected(active)
+			return
+		}
+		active = name
+		onSwitch(services)
+	}
+
+	workspaceSelect = widget.NewSelect(names, open)
+	workspaceSelect.SetSelected(active)
+
+	newButton := widget.NewButton("New Workspace", func() {
+		nameEntry := widget.NewEntry()
+		formItems := []*widget.FormItem{
+			widget.NewFormItem("Name", nameEntry),
+		}
+		dialog.ShowForm("New Workspace", "Open", "Cancel", formItems, func(valid bool) {
+			name := strings.TrimSpace(nameEntry.Text)
+			if !valid || name == "" {
+				return
+			}
+			if indexOf(workspaceSelect.Options, name) < 0 {
+				workspaceSelect.Options = uniqueSorted(append(workspaceSelect.Options, name))
+			}
+			workspaceSelect.SetSelected(name)
+		}, w)
+	})
+
+	return container.NewBorder(nil, nil, widget.NewLabel("Workspace"), newButton, workspaceSelect)
+}
+
+// Helper function: sort strings and drop duplicates and empty values.
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var result []string
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	minZoom = 0.1
+	maxZoom = 4.0
+	// zoomStep is the factor of one mouse wheel notch or zoom button press.
+	zoomStep = 1.2
+	// labelZoom is the zoom below which node and edge labels are hidden.
+	labelZoom = 0.6
+	// fitPadding is the space left around the nodes by Fit.
+	fitPadding = 20
+
+	minimapWidth  = 200
+	minimapHeight = 150
+)
+
+// zoomCanvas shows the graph in a scroll container that zooms with the mouse wheel and
+// the zoom buttons, pans when the background is dragged and overlays a minimap of the
+// whole graph. The zoom is kept in the graph layout, so that a rebuild keeps it.
+type zoomCanvas struct {
+	scroll     *container.Scroll
+	background *canvasBackground
+	minimap    *minimap
+	placement  *graphLayout
+	content    fyne.CanvasObject
+	// points are the node centres and size the size of the shown graph, kept by SetContent
+	// so that the minimap never reads the graph while it is updated.
+	points []fyne.Position
+	size   fyne.Size
+	// rebuild draws the graph at the zoom of placement and passes it to SetContent.
+	rebuild func()
+}
+
+func newZoomCanvas(placement *graphLayout, rebuild func()) *zoomCanvas {
+	c := &zoomCanvas{
+		scroll:    container.NewScroll(container.NewWithoutLayout()),
+		placement: placement,
+		rebuild:   rebuild,
+	}
+	c.scroll.SetMinSize(fyne.NewSize(800, 600))
+	c.scroll.OnScrolled = func(fyne.Position) { c.refreshMinimap() }
+	c.background = newCanvasBackground(c.ZoomBy, c.pan)
+	c.minimap = newMinimap(c.centerOn)
+	c.content = container.NewStack(c.scroll,
+		container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), c.minimap), nil, nil))
+	return c
+}
+
+// SetContent shows the graph after it was updated, with points the centres of its nodes.
+// The background that zooms and pans is put behind it unless it is there already.
+func (c *zoomCanvas) SetContent(graph *fyne.Container, points []fyne.Position) {
+	c.points, c.size = points, graph.MinSize()
+	c.background.Resize(c.size)
+	if len(graph.Objects) == 0 || graph.Objects[0] != c.background {
+		graph.Objects = append([]fyne.CanvasObject{c.background}, graph.Objects...)
+	}
+	c.scroll.Content = graph
+	c.scroll.Refresh()
+	c.refreshMinimap()
+}
+
+// ZoomBy multiplies the zoom by factor and keeps the graph point at, in zoomed
+// coordinates, under the pointer.
+func (c *zoomCanvas) ZoomBy(factor float64, at fyne.Position) {
+	old := c.placement.Zoom()
+	c.placement.SetZoom(old * factor)
+	ratio := float32(c.placement.Zoom() / old)
+	if ratio == 1 {
+		return
+	}
+	onScreen := at.Subtract(c.scroll.Offset)
+	c.rebuild()
+	c.scroll.Offset = fyne.NewPos(at.X*ratio-onScreen.X, at.Y*ratio-onScreen.Y)
+	c.scroll.Refresh()
+	c.refreshMinimap()
+}
+
+// ZoomIn zooms in one step around the centre of the visible area.
+func (c *zoomCanvas) ZoomIn() {
+	c.ZoomBy(zoomStep, c.center())
+}
+
+// ZoomOut zooms out one step around the centre of the visible area.
+func (c *zoomCanvas) ZoomOut() {
+	c.ZoomBy(1/zoomStep, c.center())
+}
+
+// Fit zooms so that every shown node fits into the visible area and scrolls to them.
+func (c *zoomCanvas) Fit() {
+	lo, hi, ok := c.placement.bounds()
+	size := c.scroll.Size()
+	if !ok || size.Width <= 2*fitPadding || size.Height <= 2*fitPadding {
+		return
+	}
+	width := hi.X - lo.X + nodeWidth
+	height := hi.Y - lo.Y + nodeHeight
+	c.placement.SetZoom(math.Min(float64(size.Width-2*fitPadding)/width, float64(size.Height-2*fitPadding)/height))
+	zoom := c.placement.Zoom()
+	c.rebuild()
+	c.scroll.Offset = fyne.NewPos(float32(lo.X*zoom)-fitPadding, float32(lo.Y*zoom)-fitPadding)
+	c.scroll.Refresh()
+	c.refreshMinimap()
+}
+
+// center returns the centre of the visible area in zoomed coordinates.
+func (c *zoomCanvas) center() fyne.Position {
+	size := c.scroll.Size()
+	return c.scroll.Offset.AddXY(size.Width/2, size.Height/2)
+}
+
+// centerOn scrolls so that the point, in zoomed coordinates, is in the middle of the
+// visible area.
+func (c *zoomCanvas) centerOn(p fyne.Position) {
+	size := c.scroll.Size()
+	c.scroll.Offset = p.SubtractXY(size.Width/2, size.Height/2)
+	c.scroll.Refresh()
+	c.refreshMinimap()
+}
+
+func (c *zoomCanvas) pan(d fyne.Delta) {
+	c.scroll.Offset = c.scroll.Offset.SubtractXY(d.DX, d.DY)
+	c.scroll.Refresh()
+	c.refreshMinimap()
+}
+
+// refreshMinimap shows the node centres of the shown graph and the visible area.
+func (c *zoomCanvas) refreshMinimap() {
+	c.minimap.points = c.points
+	c.minimap.graph = c.size
+	c.minimap.viewOffset = c.scroll.Offset
+	c.minimap.viewSize = c.scroll.Size()
+	c.minimap.Refresh()
+}
+
+// canvasBackground lies behind the graph and turns mouse wheel events into zooming and
+// drags into panning. Nodes and edge labels above it keep their own events.
+type canvasBackground struct {
+	widget.BaseWidget
+	onZoom func(factor float64, at fyne.Position)
+	onPan  func(fyne.Delta)
+}
+
+func newCanvasBackground(onZoom func(float64, fyne.Position), onPan func(fyne.Delta)) *canvasBackground {
+	b := &canvasBackground{onZoom: onZoom, onPan: onPan}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *canvasBackground) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// MinSize is zero, the transparent rectangle of the graph gives it its size.
+func (b *canvasBackground) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+// Scrolled zooms in when the wheel turns up and out when it turns down.
+func (b *canvasBackground) Scrolled(ev *fyne.ScrollEvent) {
+	switch {
+	case ev.Scrolled.DY > 0:
+		b.onZoom(zoomStep, ev.Position)
+	case ev.Scrolled.DY < 0:
+		b.onZoom(1/zoomStep, ev.Position)
+	}
+}
+
+// Dragged pans the graph along with the pointer.
+func (b *canvasBackground) Dragged(ev *fyne.DragEvent) {
+	b.onPan(ev.Dragged)
+}
+
+func (b *canvasBackground) DragEnd() {}
+
+// minimap draws the nodes of the whole graph as dots and frames the visible part.
+// Tapping or dragging in it moves the visible area there.
+type minimap struct {
+	widget.BaseWidget
+	// points are the node centres and graph the size of the graph, in zoomed coordinates.
+	points     []fyne.Position
+	graph      fyne.Size
+	viewOffset fyne.Position
+	viewSize   fyne.Size
+	onCenter   func(fyne.Position)
+}
+
+func newMinimap(onCenter func(fyne.Position)) *minimap {
+	m := &minimap{onCenter: onCenter}
+	m.ExtendBaseWidget(m)
+	return m
+}
+
+// scale returns the factor from graph to minimap coordinates.
+func (m *minimap) scale() float32 {
+	if m.graph.Width <= 0 || m.graph.Height <= 0 {
+		return 0
+	}
+	return fyne.Min(minimapWidth/m.graph.Width, minimapHeight/m.graph.Height)
+}
+
+func (m *minimap) Tapped(ev *fyne.PointEvent) {
+	m.centerAt(ev.Position)
+}
+
+func (m *minimap) Dragged(ev *fyne.DragEvent) {
+	m.centerAt(ev.Position)
+}
+
+func (m *minimap) DragEnd() {}
+
+func (m *minimap) centerAt(p fyne.Position) {
+	if scale := m.scale(); scale > 0 {
+		m.onCenter(fyne.NewPos(p.X/scale, p.Y/scale))
+	}
+}
+
+func (m *minimap) CreateRenderer() fyne.WidgetRenderer {
+	r := &minimapRenderer{minimap: m}
+	r.build()
+	return r
+}
+
+type minimapRenderer struct {
+	minimap *minimap
+	objects []fyne.CanvasObject
+}
+
+// build recreates the background, the node dots and the frame of the visible area.
+func (r *minimapRenderer) build() {
+	m := r.minimap
+	background := canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	background.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	background.StrokeWidth = 1
+	background.Resize(fyne.NewSize(minimapWidth, minimapHeight))
+	objects := []fyne.CanvasObject{background}
+
+	scale := m.scale()
+	for _, p := range m.points {
+		dot := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+		dot.Resize(fyne.NewSize(3, 3))
+		dot.Move(fyne.NewPos(p.X*scale-1, p.Y*scale-1))
+		objects = append(objects, dot)
+	}
+	frame := canvas.NewRectangle(color.Transparent)
+	frame.StrokeColor = highlightColor
+	frame.StrokeWidth = 1
+	frame.Move(fyne.NewPos(m.viewOffset.X*scale, m.viewOffset.Y*scale))
+	frame.Resize(fyne.NewSize(
+		fyne.Min(m.viewSize.Width*scale, minimapWidth),
+		fyne.Min(m.viewSize.Height*scale, minimapHeight),
+	))
+	r.objects = append(objects, frame)
+}
+
+func (r *minimapRenderer) Layout(_ fyne.Size) {}
+func (r *minimapRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(minimapWidth, minimapHeight)
+}
+func (r *minimapRenderer) Refresh() {
+	r.build()
+	canvas.Refresh(r.minimap)
+}
+func (r *minimapRenderer) Objects() []fyne.CanvasObject { return r.objects }
+func (r *minimapRenderer) Destroy()                     {}
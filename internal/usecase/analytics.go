@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+)
+
+// Analyze loads a snapshot of the workspace and computes its centrality, components,
+// communities, orphans and dead ends.
+func (uc *NodeUseCase) Analyze(ctx context.Context) (*analytics.Report, error) {
+	snapshot, err := uc.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Analyze(), nil
+}
+
+// SuggestLinks returns up to limit nodes that nodeID is not linked to yet but whose text,
+// tags or neighbours are similar, best first.
+func (uc *NodeUseCase) SuggestLinks(ctx context.Context, nodeID string, limit int) ([]analytics.Suggestion, error) {
+	if _, err := uc.repo.GetNodeByID(ctx, nodeID); err != nil {
+		return nil, err
+	}
+	snapshot, err := uc.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.SuggestLinks(nodeID, limit), nil
+}
+
+// snapshot loads every node and relationship of the workspace in a single transaction.
+func (uc *NodeUseCase) snapshot(ctx context.Context) (*analytics.Snapshot, error) {
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot *analytics.Snapshot
+	err = uc.repo.WithinTx(ctx, func(tx Tx) error {
+		var err error
+		snapshot, err = analytics.Load(ctx, tx, registry)
+		return err
+	})
+	return snapshot, err
+}
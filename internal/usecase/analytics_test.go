@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestAnalyze(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Engine", "Car", "Notes"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Engine"], TargetIDs: []string{ids["Car"]}, Type: domain.IsPartOf})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	report, err := nodes.Analyze(ctx)
+	assert.NoError(t, err, "Analyze should succeed")
+	assert.Equal(t, 1, report.Degrees[ids["Car"]].Total, "The inverse HAS_PART link should not be counted")
+	assert.Len(t, report.Components, 2)
+	if assert.Len(t, report.Orphans, 1) {
+		assert.Equal(t, "Notes", report.Orphans[0].Title)
+	}
+	if assert.Len(t, report.DeadEnds, 1) {
+		assert.Equal(t, "Car", report.DeadEnds[0].Title)
+	}
+}
+
+func TestSuggestLinks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, n := range []*domain.Node{
+		{Title: "Channels", Content: "Typed pipes between goroutines", Tags: []string{"go"}},
+		{Title: "Buffered channels", Content: "Channels with a capacity", Tags: []string{"go"}},
+		{Title: "Sourdough", Content: "Bread from a starter"},
+	} {
+		n.Type = domain.Concept
+		id, err := nodes.CreateNode(ctx, n)
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[n.Title] = id
+	}
+
+	suggestions, err := nodes.SuggestLinks(ctx, ids["Channels"], 5)
+	assert.NoError(t, err, "SuggestLinks should succeed")
+	if assert.Len(t, suggestions, 1, "Unrelated nodes should not be suggested") {
+		assert.Equal(t, "Buffered channels", suggestions[0].Node.Title)
+	}
+
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Buffered channels"], TargetIDs: []string{ids["Channels"]}, Type: domain.RelatedTo})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	suggestions, err = nodes.SuggestLinks(ctx, ids["Channels"], 5)
+	assert.NoError(t, err, "SuggestLinks should succeed")
+	assert.Empty(t, suggestions, "Linked nodes should not be suggested")
+
+	_, err = nodes.SuggestLinks(ctx, "missing", 5)
+	assert.Error(t, err, "Unknown nodes should be reported")
+}
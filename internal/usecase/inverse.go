@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// InverseMismatch is a relationship whose type has an inverse while the inverse edge
+// from its target back to its source is missing.
+type InverseMismatch struct {
+	Relationship *domain.Relationship
+	Missing      domain.RelationType
+}
+
+// CheckInverses reports every relationship whose inverse edge is missing, for example
+// an IS_PART_OF without the matching HAS_PART.
+func (uc *NodeUseCase) CheckInverses(ctx context.Context) ([]InverseMismatch, error) {
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rels, err := uc.repo.ListRelationships(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return inverseMismatches(registry, rels), nil
+}
+
+// RepairInverses creates the missing inverse edges in one transaction and returns how
+// many were created. An inverse edge that would break a rule of its type fails the
+// repair with a *RuleError.
+func (uc *NodeUseCase) RepairInverses(ctx context.Context) (int, error) {
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var repaired int
+	err = uc.repo.WithinTx(ctx, func(tx Tx) error {
+		repaired = 0
+		rels, err := tx.ListRelationships(ctx, "")
+		if err != nil {
+			return err
+		}
+		for _, m := range inverseMismatches(registry, rels) {
+			rel := m.Relationship
+			if err := ensureInverse(ctx, tx, rel, inverseDef(registry, m.Missing)); err != nil {
+				return err
+			}
+			repaired++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return repaired, nil
+}
+
+// inverseMismatches finds the relationships of rels without their inverse edge.
+func inverseMismatches(registry *domain.TypeRegistry, rels []*domain.Relationship) []InverseMismatch {
+	type edge struct {
+		source, target string
+		relType        domain.RelationType
+	}
+	existing := make(map[edge]bool, len(rels))
+	for _, rel := range rels {
+		existing[edge{rel.SourceID, rel.TargetIDs[0], rel.Type}] = true
+	}
+
+	var mismatches []InverseMismatch
+	for _, rel := range rels {
+		def, ok := registry.RelationType(rel.Type)
+		if !ok || def.Inverse == "" {
+			continue
+		}
+		if !existing[edge{rel.TargetIDs[0], rel.SourceID, def.Inverse}] {
+			mismatches = append(mismatches, InverseMismatch{Relationship: rel, Missing: def.Inverse})
+			// The repair creates this edge, so its own inverse must not be reported again.
+			existing[edge{rel.TargetIDs[0], rel.SourceID, def.Inverse}] = true
+		}
+	}
+	return mismatches
+}
+
+// ensureInverse creates the edge of type def from the target of rel back to its source
+// unless it exists. The inverse edge shares the description, weight, confidence and
+// validity period of rel. Like any other edge it must keep the rules of def; a violation
+// fails with a *RuleError.
+func ensureInverse(ctx context.Context, tx Tx, rel *domain.Relationship, def domain.RelationTypeDef) error {
+	inverse, err := findInverse(ctx, tx, rel, def.Name)
+	if err != nil || inverse != nil {
+		return err
+	}
+	inverse = &domain.Relationship{
+		SourceID:    rel.TargetIDs[0],
+		TargetIDs:   []string{rel.SourceID},
+		Type:        def.Name,
+		Description: rel.Description,
+		Weight:      rel.Weight,
+		Confidence:  rel.Confidence,
+		ValidFrom:   rel.ValidFrom,
+		ValidTo:     rel.ValidTo,
+	}
+	if err := checkRules(ctx, tx, def, inverse); err != nil {
+		return err
+	}
+	_, err = tx.CreateRelationship(ctx, inverse)
+	return err
+}
+
+// inverseDef returns the definition of the inverse type name. A type missing from the
+// registry has no rules.
+func inverseDef(registry *domain.TypeRegistry, name domain.RelationType) domain.RelationTypeDef {
+	def, ok := registry.RelationType(name)
+	if !ok {
+		def = domain.RelationTypeDef{Name: name}
+	}
+	return def
+}
+
+// findInverse returns the inverseType edge from the target of rel back to its source, or nil.
+func findInverse(ctx context.Context, tx Tx, rel *domain.Relationship, inverseType domain.RelationType) (*domain.Relationship, error) {
+	rels, err := tx.ListRelationships(ctx, rel.TargetIDs[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range rels {
+		if candidate.Type == inverseType && candidate.SourceID == rel.TargetIDs[0] && candidate.TargetIDs[0] == rel.SourceID {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestInverseRelationships(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	engineID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Engine", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	carID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Car", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	ids, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: engineID, TargetIDs: []string{carID}, Type: domain.IsPartOf})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	assert.Len(t, ids, 1, "Only the requested relationship id should be returned")
+
+	rels, err := repo.ListRelationships(ctx, carID)
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 2, "The inverse should be created")
+	assert.Equal(t, domain.HasPart, rels[1].Type)
+	assert.Equal(t, carID, rels[1].SourceID, "The inverse should point back to the source")
+
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: carID, TargetIDs: []string{engineID}, Type: domain.HasPart})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	rels, err = repo.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 3, "An existing inverse should not be duplicated")
+
+	err = nodes.DeleteRelationship(ctx, ids[0])
+	assert.NoError(t, err, "DeleteRelationship should succeed")
+	rels, err = repo.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 1, "The inverse should be deleted together with the relationship")
+
+	mismatches, err := nodes.CheckInverses(ctx)
+	assert.NoError(t, err, "CheckInverses should succeed")
+	assert.Len(t, mismatches, 1, "The remaining HAS_PART lacks its IS_PART_OF")
+	assert.Equal(t, domain.IsPartOf, mismatches[0].Missing)
+
+	repaired, err := nodes.RepairInverses(ctx)
+	assert.NoError(t, err, "RepairInverses should succeed")
+	assert.Equal(t, 1, repaired)
+	mismatches, err = nodes.CheckInverses(ctx)
+	assert.NoError(t, err, "CheckInverses should succeed")
+	assert.Empty(t, mismatches, "The graph should be consistent after the repair")
+}
+
+func TestInverseRelationshipRules(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	types := usecase.NewTypeUseCase(repo)
+
+	err := types.SaveRelationType(ctx, domain.RelationTypeDef{Name: domain.HasPart, Inverse: domain.IsPartOf, Directed: true, Rules: domain.RelationRules{
+		MaxOutDegree: 1,
+	}})
+	assert.NoError(t, err, "SaveRelationType should succeed")
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Car", "Engine", "Wheel"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Engine"], TargetIDs: []string{ids["Car"]}, Type: domain.IsPartOf})
+	assert.NoError(t, err, "The first inverse edge keeps the degree rule")
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Wheel"], TargetIDs: []string{ids["Car"]}, Type: domain.IsPartOf})
+	assert.EqualError(t, err, "HAS_PART allows at most 1 links from a node: Car")
+
+	rels, err := repo.ListRelationships(ctx, ids["Wheel"])
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Empty(t, rels, "A relationship whose inverse breaks a rule should not be written")
+
+	_, err = repo.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Wheel"], TargetIDs: []string{ids["Car"]}, Type: domain.IsPartOf})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	_, err = nodes.RepairInverses(ctx)
+	assert.ErrorIs(t, err, usecase.ErrRuleViolation, "The repair should keep the rules of the inverse type")
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// NodeByTitle returns the node titled title, ignoring case and surrounding spaces, as a
+// [[Title]] link in content refers to it. It returns nil if there is none.
+func (uc *NodeUseCase) NodeByTitle(ctx context.Context, title string) (*domain.Node, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, nil
+	}
+	candidates, err := uc.repo.SearchNodes(ctx, title, "Title/Content")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range candidates {
+		if strings.EqualFold(strings.TrimSpace(n.Title), title) {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+// Backlinks returns the nodes whose content links to the node id with a [[Title]] link.
+func (uc *NodeUseCase) Backlinks(ctx context.Context, id string) ([]*domain.Node, error) {
+	node, err := uc.repo.GetNodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(node.Title)
+	if title == "" {
+		return nil, nil
+	}
+	candidates, err := uc.repo.SearchNodes(ctx, title, "Title/Content")
+	if err != nil {
+		return nil, err
+	}
+	var linking []*domain.Node
+	for _, n := range candidates {
+		if n.ID == id {
+			continue
+		}
+		for _, link := range domain.WikiLinks(n.Content) {
+			if strings.EqualFold(link, title) {
+				linking = append(linking, n)
+				break
+			}
+		}
+	}
+	return linking, nil
+}
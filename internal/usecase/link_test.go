@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestWikiLinks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	content := "See [[Closures]] and [[ Generics ]], then [[closures]] again. [[]] is no link."
+	assert.Equal(t, []string{"Closures", "Generics"}, domain.WikiLinks(content))
+	replaced := domain.ReplaceWikiLinks("Read [[ Closures ]].", func(title string) string { return "<" + title + ">" })
+	assert.Equal(t, "Read <Closures>.", replaced)
+
+	id, err := nodes.CreateNode(ctx, &domain.Node{Title: "Closures", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = nodes.CreateNode(ctx, &domain.Node{Title: "Closures in Go", Content: "closures", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	found, err := nodes.NodeByTitle(ctx, " closures ")
+	assert.NoError(t, err, "NodeByTitle should succeed")
+	if assert.NotNil(t, found, "The title should match ignoring case") {
+		assert.Equal(t, id, found.ID, "Only the exact title should match")
+	}
+	found, err = nodes.NodeByTitle(ctx, "Generics")
+	assert.NoError(t, err, "NodeByTitle should succeed")
+	assert.Nil(t, found, "A missing title should find nothing")
+}
+
+func TestBacklinks(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	id, err := nodes.CreateNode(ctx, &domain.Node{Title: "Closures", Content: "See [[Closures]].", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	linking, err := nodes.CreateNode(ctx, &domain.Node{Title: "Functions", Content: "Read [[ closures ]].", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = nodes.CreateNode(ctx, &domain.Node{Title: "Mentions", Content: "Closures without a link.", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = nodes.CreateNode(ctx, &domain.Node{Title: "Other", Content: "[[Closures in Go]]", Type: domain.Note})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	backlinks, err := nodes.Backlinks(ctx, id)
+	assert.NoError(t, err, "Backlinks should succeed")
+	if assert.Len(t, backlinks, 1, "Only a [[Title]] link from another node is a backlink") {
+		assert.Equal(t, linking, backlinks[0].ID)
+	}
+}
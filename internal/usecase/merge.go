@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/analytics"
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// FindDuplicates returns the pairs of nodes that probably describe the same thing, best
+// first.
+func (uc *NodeUseCase) FindDuplicates(ctx context.Context) ([]analytics.Duplicate, error) {
+	snapshot, err := uc.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Duplicates(), nil
+}
+
+// MergeNodes merges mergedID into survivorID in a single transaction. The survivor takes
+// the title and content of opts, the union of both tag sets and the properties of the
+// merged node it has no value for, as far as its type declares them. Every relationship
+// of the merged node is moved to the survivor with its id and attributes, except links
+// between the two nodes and links the survivor already has, which are deleted. The merged
+// node is deleted with its former versions. The returned record keeps them and undoes the
+// merge with UndoMerge.
+func (uc *NodeUseCase) MergeNodes(ctx context.Context, survivorID, mergedID string, opts domain.MergeOptions) (*domain.MergeRecord, error) {
+	if survivorID == mergedID {
+		return nil, fmt.Errorf("%w: a node cannot be merged into itself", ErrInvalidMerge)
+	}
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var record *domain.MergeRecord
+	err = uc.repo.WithinTx(ctx, func(tx Tx) error {
+		survivor, err := tx.GetNodeByID(ctx, survivorID)
+		if err != nil {
+			return err
+		}
+		merged, err := tx.GetNodeByID(ctx, mergedID)
+		if err != nil {
+			return err
+		}
+		rels, err := tx.ListRelationships(ctx, mergedID)
+		if err != nil {
+			return err
+		}
+		existing, err := tx.ListRelationships(ctx, survivorID)
+		if err != nil {
+			return err
+		}
+		revisions, err := tx.ListNodeRevisions(ctx, mergedID)
+		if err != nil {
+			return err
+		}
+		before := *survivor
+		record = &domain.MergeRecord{Survivor: &before, Merged: merged, Relationships: rels, Revisions: revisions, MergedAt: time.Now()}
+
+		combined := *survivor
+		combined.Title = opts.Title
+		combined.Content = opts.Content
+		combined.Tags = domain.NormalizeTags(append(append([]string(nil), survivor.Tags...), merged.Tags...))
+		combined.Properties = make(map[string]string, len(survivor.Properties))
+		for name, value := range survivor.Properties {
+			combined.Properties[name] = value
+		}
+		def, _ := registry.NodeType(survivor.Type)
+		for name, value := range merged.Properties {
+			if _, declared := def.Property(name); declared && combined.Properties[name] == "" {
+				combined.Properties[name] = value
+			}
+		}
+		// The registry was read before the transaction, as reading it within would block
+		// the in-memory store.
+		if combined.Properties, err = normalizeProperties(def, combined.Properties); err != nil {
+			return err
+		}
+
+		links := make(map[[3]string]bool, len(existing))
+		for _, rel := range existing {
+			links[linkKey(rel)] = true
+		}
+		for _, rel := range rels {
+			if err := tx.DeleteRelationship(ctx, rel.ID); err != nil {
+				return err
+			}
+			moved := *rel
+			moved.TargetIDs = []string{rel.TargetIDs[0]}
+			if moved.SourceID == mergedID {
+				moved.SourceID = survivorID
+			}
+			if moved.TargetIDs[0] == mergedID {
+				moved.TargetIDs[0] = survivorID
+			}
+			if moved.SourceID == moved.TargetIDs[0] || links[linkKey(&moved)] {
+				record.Dropped = append(record.Dropped, rel.ID)
+				continue
+			}
+			relDef, _ := registry.RelationType(moved.Type)
+			if err := checkRules(ctx, tx, relDef, &moved); err != nil {
+				return err
+			}
+			links[linkKey(&moved)] = true
+			if err := tx.RestoreRelationship(ctx, &moved); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.UpdateNode(ctx, &combined); err != nil {
+			return err
+		}
+		return tx.DeleteNode(ctx, mergedID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// UndoMerge restores the state before the merge described by record: the survivor gets
+// back its title, content, tags and properties, the merged node is recreated with its id
+// and former versions and every relationship it had is restored. Changes made to the survivor since the merge
+// are lost. Undoing fails if the survivor was deleted or the merged node exists.
+func (uc *NodeUseCase) UndoMerge(ctx context.Context, record *domain.MergeRecord) error {
+	return uc.repo.WithinTx(ctx, func(tx Tx) error {
+		if _, err := tx.GetNodeByID(ctx, record.Merged.ID); err == nil {
+			return fmt.Errorf("%w: node %s exists", ErrInvalidMerge, record.Merged.ID)
+		}
+		if _, err := tx.GetNodeByID(ctx, record.Survivor.ID); err != nil {
+			return err
+		}
+		for _, rel := range record.Relationships {
+			if err := tx.DeleteRelationship(ctx, rel.ID); err != nil {
+				return err
+			}
+		}
+		survivor := *record.Survivor
+		if err := tx.UpdateNode(ctx, &survivor); err != nil {
+			return err
+		}
+		if err := tx.RestoreNode(ctx, record.Merged); err != nil {
+			return err
+		}
+		if err := tx.RestoreNodeRevisions(ctx, record.Merged.ID, record.Revisions); err != nil {
+			return err
+		}
+		for _, rel := range record.Relationships {
+			if err := tx.RestoreRelationship(ctx, rel); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// linkKey identifies a link by type and endpoints.
+func linkKey(rel *domain.Relationship) [3]string {
+	return [3]string{string(rel.Type), rel.SourceID, rel.TargetIDs[0]}
+}
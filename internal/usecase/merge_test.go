@@ -0,0 +1,118 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestMergeNodes(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, n := range []*domain.Node{
+		{Title: "Goroutines", Tags: []string{"go"}},
+		{Title: "goroutine", Content: "Lightweight threads", Tags: []string{"concurrency"}},
+		{Title: "Channels"},
+		{Title: "Scheduler"},
+	} {
+		n.Type = domain.Concept
+		id, err := nodes.CreateNode(ctx, n)
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[n.Title] = id
+	}
+	link := func(from, to string, relType domain.RelationType) string {
+		relIDs, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[from], TargetIDs: []string{ids[to]}, Type: relType})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+		return relIDs[0]
+	}
+	link("Goroutines", "Channels", domain.DependsOn)
+	link("goroutine", "Channels", domain.DependsOn)
+	partID := link("Scheduler", "goroutine", domain.HasPart)
+	link("Goroutines", "goroutine", domain.RelatedTo)
+	edited, err := nodes.GetNode(ctx, ids["goroutine"])
+	assert.NoError(t, err, "GetNode should succeed")
+	edited.Content = "Green threads"
+	assert.NoError(t, nodes.UpdateNode(ctx, edited), "UpdateNode should succeed")
+
+	duplicates, err := nodes.FindDuplicates(ctx)
+	assert.NoError(t, err, "FindDuplicates should succeed")
+	if assert.Len(t, duplicates, 1) {
+		assert.Equal(t, ids["Goroutines"], duplicates[0].A.ID, "The older node should come first")
+	}
+
+	_, err = nodes.MergeNodes(ctx, ids["Channels"], ids["Channels"], domain.MergeOptions{Title: "Channels"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidMerge, "A node should not be merged into itself")
+
+	record, err := nodes.MergeNodes(ctx, ids["Goroutines"], ids["goroutine"], domain.MergeOptions{Title: "Goroutines", Content: "Lightweight threads"})
+	assert.NoError(t, err, "MergeNodes should succeed")
+	assert.Len(t, record.Dropped, 2, "The duplicate link and the link between the nodes should be dropped")
+	assert.Len(t, record.Revisions, 1, "The record should keep the former versions of the merged node")
+
+	_, err = nodes.GetNode(ctx, ids["goroutine"])
+	assert.Error(t, err, "The merged node should be deleted")
+	survivor, err := nodes.GetNode(ctx, ids["Goroutines"])
+	assert.NoError(t, err, "GetNode should succeed")
+	assert.Equal(t, "Lightweight threads", survivor.Content)
+	assert.ElementsMatch(t, []string{"go", "concurrency"}, survivor.Tags, "Tags should be combined")
+	rels, err := nodes.ListRelationships(ctx, ids["Goroutines"])
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 3)
+	assert.Contains(t, relationshipIDs(rels), partID, "Moved relationships should keep their id")
+	assert.Empty(t, mustCheckInverses(t, nodes), "Inverse pairs should be moved together")
+
+	err = nodes.UndoMerge(ctx, record)
+	assert.NoError(t, err, "UndoMerge should succeed")
+	restored, err := nodes.GetNode(ctx, ids["goroutine"])
+	assert.NoError(t, err, "The merged node should be restored")
+	assert.Equal(t, "goroutine", restored.Title)
+	revisions, err := nodes.NodeHistory(ctx, ids["goroutine"])
+	assert.NoError(t, err, "NodeHistory should succeed")
+	if assert.Len(t, revisions, 1, "The former versions of the merged node should be restored") {
+		assert.Equal(t, "Lightweight threads", revisions[0].Content)
+	}
+	survivor, err = nodes.GetNode(ctx, ids["Goroutines"])
+	assert.NoError(t, err, "GetNode should succeed")
+	assert.Equal(t, []string{"go"}, survivor.Tags, "The survivor should be restored")
+	rels, err = nodes.ListRelationships(ctx, ids["goroutine"])
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.ElementsMatch(t, relationshipIDs(record.Relationships), relationshipIDs(rels), "Relationships should be restored with their ids")
+	assert.Error(t, nodes.UndoMerge(ctx, record), "A merge should be undone only once")
+}
+
+func TestMergeNodesRules(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"A", "B", "C"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	for _, l := range [][2]string{{"A", "B"}, {"C", "A"}} {
+		_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[l[0]], TargetIDs: []string{ids[l[1]]}, Type: domain.DependsOn})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+
+	_, err := nodes.MergeNodes(ctx, ids["C"], ids["B"], domain.MergeOptions{Title: "C"})
+	assert.ErrorIs(t, err, usecase.ErrRuleViolation, "A merge closing a DEPENDS_ON cycle should be rejected")
+	_, err = nodes.GetNode(ctx, ids["B"])
+	assert.NoError(t, err, "A rejected merge should be rolled back")
+}
+
+func relationshipIDs(rels []*domain.Relationship) []string {
+	ids := make([]string, len(rels))
+	for i, rel := range rels {
+		ids[i] = rel.ID
+	}
+	return ids
+}
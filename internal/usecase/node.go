@@ -7,16 +7,38 @@ import (
 )
 
 type NodeUseCase struct {
-	repo NodeRepository
+	repo  NodeRepository
+	types *TypeUseCase
 }
 
-func NewNodeUseCase(repo NodeRepository) *NodeUseCase {
+// NewNodeUseCase returns a use case that validates node and relationship types against
+// the type registry stored in types.
+func NewNodeUseCase(repo NodeRepository, types TypeRepository) *NodeUseCase {
 	return &NodeUseCase{
-		repo: repo,
+		repo:  repo,
+		types: NewTypeUseCase(types),
 	}
 }
 
+// Workspace returns the active workspace.
+func (uc *NodeUseCase) Workspace() domain.Workspace {
+	return uc.repo.Workspace()
+}
+
+func (uc *NodeUseCase) ListWorkspaces(ctx context.Context) ([]string, error) {
+	return uc.repo.ListWorkspaces(ctx)
+}
+
+// WithinTx runs several repository operations so that they commit or roll back together.
+func (uc *NodeUseCase) WithinTx(ctx context.Context, fn func(tx Tx) error) error {
+	return uc.repo.WithinTx(ctx, fn)
+}
+
 func (uc *NodeUseCase) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
+	node.Tags = domain.NormalizeTags(node.Tags)
+	if err := uc.types.ValidateNode(ctx, node); err != nil {
+		return "", err
+	}
 	return uc.repo.CreateNode(ctx, node)
 }
 
@@ -24,20 +46,93 @@ func (uc *NodeUseCase) GetNode(ctx context.Context, id string) (*domain.Node, er
 	return uc.repo.GetNodeByID(ctx, id)
 }
 
+// CreateRelationship links the source to every target and returns the ids of the new
+// relationships. If the type has an inverse, the inverse edge from every target back to
+// the source is created in the same transaction unless it already exists. The rules of
+// the type, and of the inverse type for the inverse edges, are checked first; a violation
+// fails with a *RuleError.
 func (uc *NodeUseCase) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
-	return uc.repo.CreateRelationship(ctx, rel)
+	if err := validateRelationship(rel); err != nil {
+		return nil, err
+	}
+	def, err := uc.types.RelationType(ctx, rel.Type)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	err = uc.repo.WithinTx(ctx, func(tx Tx) error {
+		if err := checkRules(ctx, tx, def, rel); err != nil {
+			return err
+		}
+		created, err := tx.CreateRelationship(ctx, rel)
+		if err != nil {
+			return err
+		}
+		ids = created
+		if def.Inverse == "" {
+			return nil
+		}
+		for _, targetID := range rel.TargetIDs {
+			single := *rel
+			single.TargetIDs = []string{targetID}
+			if err := ensureInverse(ctx, tx, &single, inverseDef(registry, def.Inverse)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
 
 func (uc *NodeUseCase) UpdateNode(ctx context.Context, node *domain.Node) error {
+	node.Tags = domain.NormalizeTags(node.Tags)
+	if err := uc.types.ValidateNode(ctx, node); err != nil {
+		return err
+	}
 	return uc.repo.UpdateNode(ctx, node)
 }
 
+// NodeHistory returns the former versions of the node, newest first.
+func (uc *NodeUseCase) NodeHistory(ctx context.Context, id string) ([]domain.NodeRevision, error) {
+	return uc.repo.ListNodeRevisions(ctx, id)
+}
+
 func (uc *NodeUseCase) DeleteNode(ctx context.Context, id string) error {
 	return uc.repo.DeleteNode(ctx, id)
 }
 
+// DeleteRelationship deletes the relationship together with its inverse edge, if any.
 func (uc *NodeUseCase) DeleteRelationship(ctx context.Context, relationshipID string) error {
-	return uc.repo.DeleteRelationship(ctx, relationshipID)
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.repo.WithinTx(ctx, func(tx Tx) error {
+		rel, err := tx.GetRelationship(ctx, relationshipID)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteRelationship(ctx, relationshipID); err != nil {
+			return err
+		}
+		def, _ := registry.RelationType(rel.Type)
+		if def.Inverse == "" {
+			return nil
+		}
+		inverse, err := findInverse(ctx, tx, rel, def.Inverse)
+		if err != nil || inverse == nil {
+			return err
+		}
+		return tx.DeleteRelationship(ctx, inverse.ID)
+	})
 }
 
 func (uc *NodeUseCase) SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error) {
@@ -6,12 +6,54 @@ import (
 	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
 )
 
-type NodeRepository interface {
+// Tx is the set of repository operations that can be grouped into a single transaction.
+type Tx interface {
 	CreateNode(context.Context, *domain.Node) (string, error)
 	GetNodeByID(context.Context, string) (*domain.Node, error)
 	CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error)
 	UpdateNode(ctx context.Context, node *domain.Node) error
 	DeleteNode(ctx context.Context, id string) error
 	DeleteRelationship(ctx context.Context, relationshipID string) error
+	// UpdateRelationship replaces the type, description and attributes of the relationship
+	// rel.ID. Its id, endpoints and creation time are kept.
+	UpdateRelationship(ctx context.Context, rel *domain.Relationship) error
 	SearchNodes(ctx context.Context, query, criteria string) ([]*domain.Node, error)
+	// GetRelationship returns a single relationship; TargetIDs holds exactly one target.
+	GetRelationship(ctx context.Context, relationshipID string) (*domain.Relationship, error)
+	// ListRelationships returns the relationships starting or ending at nodeID, or every
+	// relationship of the workspace if nodeID is empty. Each has exactly one target.
+	ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error)
+	// RestoreNode stores node with its id and timestamps as given, e.g. to undo a deletion.
+	RestoreNode(ctx context.Context, node *domain.Node) error
+	// RestoreRelationship stores rel with its id and creation time as given. rel has
+	// exactly one target; both endpoints must exist.
+	RestoreRelationship(ctx context.Context, rel *domain.Relationship) error
+	// ListNodeRevisions returns the former versions of the node, newest first. A version is
+	// kept by every UpdateNode, up to domain.MaxNodeRevisions, and removed together with
+	// the node.
+	ListNodeRevisions(ctx context.Context, nodeID string) ([]domain.NodeRevision, error)
+	// RestoreNodeRevisions stores revisions, newest first as ListNodeRevisions returns them,
+	// as former versions of the node nodeID, e.g. to undo its deletion.
+	RestoreNodeRevisions(ctx context.Context, nodeID string, revisions []domain.NodeRevision) error
+}
+
+type NodeRepository interface {
+	Tx
+	// WithinTx runs fn in a single transaction. The transaction is committed when fn
+	// returns nil and rolled back otherwise. fn may be retried on transient errors,
+	// so it must not have side effects outside of tx.
+	WithinTx(ctx context.Context, fn func(tx Tx) error) error
+	// Workspace returns the workspace every operation of the repository is scoped to.
+	Workspace() domain.Workspace
+	// ForWorkspace returns a repository scoped to ws that shares the underlying storage.
+	ForWorkspace(ws domain.Workspace) NodeRepository
+	// FindPaths returns the shortest paths between two nodes, following relationships in
+	// both directions. Relationship types in opts must have been validated.
+	FindPaths(ctx context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error)
+	// GetNeighborhood returns the node id with every node reachable within depth hops along
+	// relationships matching filter, and the matching relationships among those nodes.
+	// Relationship types in filter must have been validated.
+	GetNeighborhood(ctx context.Context, id string, depth int, filter domain.NeighborhoodFilter) (*domain.Neighborhood, error)
+	// ListWorkspaces returns the names of the workspaces that hold nodes in the repository's database.
+	ListWorkspaces(ctx context.Context) ([]string, error)
 }
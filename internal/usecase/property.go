@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+var (
+	ErrInvalidPropertyDef = errors.New("invalid property definition")
+	ErrUnknownProperty    = errors.New("property is not defined for the node type")
+	ErrInvalidProperty    = errors.New("invalid property value")
+	ErrMissingProperty    = errors.New("required property is missing")
+)
+
+// propertyNamePattern keeps property names usable as Neo4j property keys without quoting.
+var propertyNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validatePropertyDefs checks the property schema of a node type.
+func validatePropertyDefs(defs []domain.PropertyDef) error {
+	seen := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		if !propertyNamePattern.MatchString(def.Name) {
+			return fmt.Errorf("%w: name %q must be lower case letters, digits and underscores", ErrInvalidPropertyDef, def.Name)
+		}
+		if seen[def.Name] {
+			return fmt.Errorf("%w: duplicate property %s", ErrInvalidPropertyDef, def.Name)
+		}
+		seen[def.Name] = true
+
+		switch def.Kind {
+		case domain.PropertyString, domain.PropertyNumber, domain.PropertyBool, domain.PropertyDate, domain.PropertyURL:
+			if len(def.Options) > 0 {
+				return fmt.Errorf("%w: only enum properties have options", ErrInvalidPropertyDef)
+			}
+		case domain.PropertyEnum:
+			if len(def.Options) == 0 {
+				return fmt.Errorf("%w: enum property %s needs options", ErrInvalidPropertyDef, def.Name)
+			}
+		default:
+			return fmt.Errorf("%w: unknown kind %q of %s", ErrInvalidPropertyDef, def.Kind, def.Name)
+		}
+	}
+	return nil
+}
+
+// normalizeProperties checks the properties against the schema of the node type and
+// returns them in canonical form. Empty values are dropped.
+func normalizeProperties(def domain.NodeTypeDef, properties map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(properties))
+	for name, value := range properties {
+		propDef, ok := def.Property(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s on %s", ErrUnknownProperty, name, def.Name)
+		}
+		value, err := normalizePropertyValue(propDef, value)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			normalized[name] = value
+		}
+	}
+	for _, propDef := range def.Properties {
+		if propDef.Required && normalized[propDef.Name] == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingProperty, propDef.Name)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+// normalizePropertyValue parses value according to the property kind and formats it
+// canonically: numbers without trailing zeros, booleans as true/false and dates as
+// domain.PropertyDateLayout.
+func normalizePropertyValue(def domain.PropertyDef, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s %q %s", ErrInvalidProperty, def.Name, value, reason)
+	}
+
+	switch def.Kind {
+	case domain.PropertyNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", invalid("is not a number")
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	case domain.PropertyBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", invalid("is not true or false")
+		}
+		return strconv.FormatBool(b), nil
+	case domain.PropertyDate:
+		d, err := time.Parse(domain.PropertyDateLayout, value)
+		if err != nil {
+			return "", invalid("is not a date like " + domain.PropertyDateLayout)
+		}
+		return d.Format(domain.PropertyDateLayout), nil
+	case domain.PropertyURL:
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return "", invalid("is not an absolute URL")
+		}
+		return u.String(), nil
+	case domain.PropertyEnum:
+		for _, option := range def.Options {
+			if option == value {
+				return value, nil
+			}
+		}
+		return "", invalid("is not one of " + strings.Join(def.Options, ", "))
+	default:
+		return value, nil
+	}
+}
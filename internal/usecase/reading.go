@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/graph"
+)
+
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// prerequisiteTypes are the relationship types whose target has to be read before their source.
+var prerequisiteTypes = map[domain.RelationType]bool{
+	domain.DependsOn:    true,
+	domain.IsPrecededBy: true,
+}
+
+// ReadingList returns nodeID and all its direct and indirect prerequisites along DEPENDS_ON
+// and IS_PRECEDED_BY links, ordered so that every node comes after its prerequisites. The
+// list ends with nodeID. A cycle among the prerequisites fails with ErrDependencyCycle and
+// the titles along the cycle.
+func (uc *NodeUseCase) ReadingList(ctx context.Context, nodeID string) ([]*domain.Node, error) {
+	if _, err := uc.repo.GetNodeByID(ctx, nodeID); err != nil {
+		return nil, err
+	}
+	rels, err := uc.repo.ListRelationships(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var prerequisites []*domain.Relationship
+	for _, rel := range rels {
+		if prerequisiteTypes[rel.Type] {
+			prerequisites = append(prerequisites, rel)
+		}
+	}
+
+	order, err := graph.NewDirected(prerequisites).TopologicalOrder(nodeID)
+	var cycle *graph.CycleError
+	if errors.As(err, &cycle) {
+		titles := make([]string, len(cycle.Path))
+		for i, id := range cycle.Path {
+			titles[i] = nodeTitle(ctx, uc.repo, id)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(titles, " -> "))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*domain.Node, 0, len(order))
+	for _, id := range order {
+		node, err := uc.repo.GetNodeByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
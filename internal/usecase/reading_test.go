@@ -0,0 +1,51 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestReadingList(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Basics", "Functions", "Closures", "Generics"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	for _, link := range []struct {
+		source, target string
+		relType        domain.RelationType
+	}{
+		{"Closures", "Functions", domain.DependsOn},
+		{"Functions", "Basics", domain.IsPrecededBy},
+		{"Closures", "Generics", domain.RelatedTo},
+	} {
+		_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[link.source], TargetIDs: []string{ids[link.target]}, Type: link.relType})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+
+	list, err := nodes.ReadingList(ctx, ids["Closures"])
+	assert.NoError(t, err, "ReadingList should succeed")
+	var titles []string
+	for _, n := range list {
+		titles = append(titles, n.Title)
+	}
+	assert.Equal(t, []string{"Basics", "Functions", "Closures"}, titles, "Only prerequisites should be listed, in reading order")
+
+	// Data written before the rules existed may contain cycles.
+	_, err = repo.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Basics"], TargetIDs: []string{ids["Closures"]}, Type: domain.DependsOn})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	_, err = nodes.ReadingList(ctx, ids["Closures"])
+	assert.ErrorIs(t, err, usecase.ErrDependencyCycle, "Cycles should be reported")
+	assert.EqualError(t, err, "dependency cycle: Closures -> Functions -> Basics -> Closures")
+}
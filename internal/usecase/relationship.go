@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/graph"
+)
+
+var (
+	ErrInvalidRelationship = errors.New("invalid relationship")
+	ErrNoPath              = errors.New("no path between the nodes")
+	ErrInvalidPathOptions  = errors.New("invalid path options")
+)
+
+// ListRelationships returns the relationships of nodeID, or of the whole workspace if nodeID is empty.
+func (uc *NodeUseCase) ListRelationships(ctx context.Context, nodeID string) ([]*domain.Relationship, error) {
+	return uc.repo.ListRelationships(ctx, nodeID)
+}
+
+// GetRelationship returns a single relationship; TargetIDs holds exactly one target.
+func (uc *NodeUseCase) GetRelationship(ctx context.Context, relationshipID string) (*domain.Relationship, error) {
+	return uc.repo.GetRelationship(ctx, relationshipID)
+}
+
+// UpdateRelationship changes the type, description and attributes of the relationship
+// rel.ID, keeping its id, endpoints and creation time. Like CreateRelationship it checks
+// the rules of the new type. The inverse edge follows: it is
+// updated to the inverse of the new type, created if the new type gains an inverse, or
+// deleted if it loses one.
+func (uc *NodeUseCase) UpdateRelationship(ctx context.Context, rel *domain.Relationship) error {
+	if err := validateRelationship(rel); err != nil {
+		return err
+	}
+	def, err := uc.types.RelationType(ctx, rel.Type)
+	if err != nil {
+		return err
+	}
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.WithinTx(ctx, func(tx Tx) error {
+		old, err := tx.GetRelationship(ctx, rel.ID)
+		if err != nil {
+			return err
+		}
+		updated := *rel
+		updated.SourceID, updated.TargetIDs = old.SourceID, old.TargetIDs
+		if err := checkRules(ctx, tx, def, &updated); err != nil {
+			return err
+		}
+
+		var inverse *domain.Relationship
+		if oldDef, _ := registry.RelationType(old.Type); oldDef.Inverse != "" {
+			if inverse, err = findInverse(ctx, tx, old, oldDef.Inverse); err != nil {
+				return err
+			}
+		}
+		if err := tx.UpdateRelationship(ctx, rel); err != nil {
+			return err
+		}
+
+		switch {
+		case def.Inverse == "" && inverse != nil:
+			return tx.DeleteRelationship(ctx, inverse.ID)
+		case def.Inverse == "":
+			return nil
+		case inverse == nil:
+			return ensureInverse(ctx, tx, &updated, inverseDef(registry, def.Inverse))
+		default:
+			return tx.UpdateRelationship(ctx, &domain.Relationship{
+				ID:          inverse.ID,
+				Type:        def.Inverse,
+				Description: rel.Description,
+				Weight:      rel.Weight,
+				Confidence:  rel.Confidence,
+				ValidFrom:   rel.ValidFrom,
+				ValidTo:     rel.ValidTo,
+			})
+		}
+	})
+}
+
+// RelationshipsAt returns the relationships of the workspace that are valid on the day of at.
+func (uc *NodeUseCase) RelationshipsAt(ctx context.Context, at time.Time) ([]*domain.Relationship, error) {
+	rels, err := uc.repo.ListRelationships(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return validAt(rels, at), nil
+}
+
+// FindPaths returns the shortest paths between fromID and toID as opts describe. It fails
+// with ErrNoPath if there is none. The repository searches the paths with the fewest hops;
+// weighted searches and searches at a date run over the relationships of the workspace
+// loaded into memory, which Dijkstra's algorithm and the validity periods need.
+func (uc *NodeUseCase) FindPaths(ctx context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error) {
+	if opts.MaxDepth < 0 {
+		return nil, fmt.Errorf("%w: max depth must not be negative", ErrInvalidPathOptions)
+	}
+	for _, relType := range opts.RelationTypes {
+		if _, err := uc.types.RelationType(ctx, relType); err != nil {
+			return nil, err
+		}
+	}
+	var paths []domain.Path
+	var err error
+	if opts.Weighted || opts.At != nil {
+		paths, err = uc.findPathsInMemory(ctx, fromID, toID, opts)
+	} else {
+		paths, err = uc.repo.FindPaths(ctx, fromID, toID, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, ErrNoPath
+	}
+	return paths, nil
+}
+
+// findPathsInMemory is FindPaths over the relationships of the workspace.
+func (uc *NodeUseCase) findPathsInMemory(ctx context.Context, fromID, toID string, opts domain.PathOptions) ([]domain.Path, error) {
+	registry, err := uc.types.Registry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	all, err := uc.repo.ListRelationships(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if opts.At != nil {
+		all = validAt(all, *opts.At)
+	}
+	types := make(map[domain.RelationType]bool, len(opts.RelationTypes))
+	for _, t := range opts.RelationTypes {
+		types[t] = true
+	}
+	var rels []*domain.Relationship
+	for _, rel := range all {
+		if len(types) == 0 || types[rel.Type] {
+			rels = append(rels, rel)
+		}
+	}
+
+	if !opts.Weighted {
+		return graph.NewUndirected(rels).ShortestPaths(fromID, toID, opts.Depth(), opts.All), nil
+	}
+	path, ok := graph.New(rels, registry).ShortestPath(fromID, toID)
+	if !ok {
+		return nil, nil
+	}
+	return []domain.Path{path}, nil
+}
+
+// GetNeighborhood returns the node id, the nodes within depth hops of it along the
+// relationships matching filter, and the matching relationships among them. depth is at
+// most domain.MaxNeighborhoodDepth.
+func (uc *NodeUseCase) GetNeighborhood(ctx context.Context, id string, depth int, filter domain.NeighborhoodFilter) (*domain.Neighborhood, error) {
+	if depth < 0 || depth > domain.MaxNeighborhoodDepth {
+		return nil, fmt.Errorf("%w: depth must be between 0 and %d", ErrInvalidPathOptions, domain.MaxNeighborhoodDepth)
+	}
+	valid := false
+	for _, d := range domain.Directions {
+		valid = valid || d == filter.Direction
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w: unknown direction %q", ErrInvalidPathOptions, filter.Direction)
+	}
+	for _, relType := range filter.RelationTypes {
+		if _, err := uc.types.RelationType(ctx, relType); err != nil {
+			return nil, err
+		}
+	}
+	return uc.repo.GetNeighborhood(ctx, id, depth, filter)
+}
+
+// validateRelationship checks the optional attributes of rel and truncates its validity
+// bounds to whole days.
+func validateRelationship(rel *domain.Relationship) error {
+	if rel.Weight < 0 || math.IsNaN(rel.Weight) || math.IsInf(rel.Weight, 0) {
+		return fmt.Errorf("%w: weight must be a non-negative number", ErrInvalidRelationship)
+	}
+	if rel.Confidence < 0 || rel.Confidence > 1 || math.IsNaN(rel.Confidence) {
+		return fmt.Errorf("%w: confidence must be between 0 and 1", ErrInvalidRelationship)
+	}
+	if rel.ValidFrom != nil {
+		from := domain.DateOf(*rel.ValidFrom)
+		rel.ValidFrom = &from
+	}
+	if rel.ValidTo != nil {
+		to := domain.DateOf(*rel.ValidTo)
+		rel.ValidTo = &to
+	}
+	if rel.ValidFrom != nil && rel.ValidTo != nil && rel.ValidTo.Before(*rel.ValidFrom) {
+		return fmt.Errorf("%w: valid to is before valid from", ErrInvalidRelationship)
+	}
+	return nil
+}
+
+func validAt(rels []*domain.Relationship, at time.Time) []*domain.Relationship {
+	var valid []*domain.Relationship
+	for _, rel := range rels {
+		if rel.ValidAt(at) {
+			valid = append(valid, rel)
+		}
+	}
+	return valid
+}
@@ -0,0 +1,226 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestRelationshipAttributes(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	date := func(s string) *time.Time {
+		d, _ := time.Parse(domain.PropertyDateLayout, s)
+		return &d
+	}
+	ids := make(map[string]string)
+	for _, title := range []string{"Go", "Generics", "Iterators"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+
+	for _, rel := range []*domain.Relationship{
+		{Weight: -1},
+		{Confidence: 1.5},
+		{ValidFrom: date("2024-01-01"), ValidTo: date("2023-01-01")},
+	} {
+		rel.SourceID, rel.TargetIDs, rel.Type = ids["Go"], []string{ids["Generics"]}, domain.HasPart
+		_, err := nodes.CreateRelationship(ctx, rel)
+		assert.ErrorIs(t, err, usecase.ErrInvalidRelationship, "Invalid attributes should be rejected")
+	}
+
+	_, err := nodes.CreateRelationship(ctx, &domain.Relationship{
+		SourceID: ids["Go"], TargetIDs: []string{ids["Generics"]}, Type: domain.HasPart,
+		Weight: 2, Confidence: 0.9, ValidFrom: date("2022-03-15"),
+	})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{
+		SourceID: ids["Go"], TargetIDs: []string{ids["Iterators"]}, Type: domain.DependsOn,
+		ValidFrom: date("2024-08-13"), ValidTo: date("2024-12-31"),
+	})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	rels, err := nodes.ListRelationships(ctx, ids["Generics"])
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 2, "The inverse edge should be created")
+	for _, rel := range rels {
+		assert.Equal(t, 2.0, rel.Weight, "The inverse edge should share the weight")
+		assert.Equal(t, 0.9, rel.Confidence, "The inverse edge should share the confidence")
+		assert.Equal(t, date("2022-03-15"), rel.ValidFrom, "The inverse edge should share the validity period")
+	}
+
+	valid, err := nodes.RelationshipsAt(ctx, *date("2023-06-01"))
+	assert.NoError(t, err, "RelationshipsAt should succeed")
+	assert.Len(t, valid, 2, "Only the generics links were valid in 2023")
+	valid, err = nodes.RelationshipsAt(ctx, time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC))
+	assert.NoError(t, err, "RelationshipsAt should succeed")
+	assert.Len(t, valid, 3, "Validity bounds should include the whole day")
+
+	paths, err := nodes.FindPaths(ctx, ids["Generics"], ids["Iterators"], domain.PathOptions{Weighted: true})
+	assert.NoError(t, err, "FindPaths should succeed")
+	if assert.Len(t, paths, 1, "A weighted search should find a single path") {
+		assert.Equal(t, []string{ids["Generics"], ids["Go"], ids["Iterators"]}, paths[0].NodeIDs)
+		assert.Equal(t, 3.0, paths[0].Cost, "The weight of the inverse edge should be used")
+	}
+	_, err = nodes.FindPaths(ctx, ids["Generics"], ids["Iterators"], domain.PathOptions{Weighted: true, At: date("2025-01-01")})
+	assert.ErrorIs(t, err, usecase.ErrNoPath, "Expired links should not be followed")
+	paths, err = nodes.FindPaths(ctx, ids["Generics"], ids["Iterators"], domain.PathOptions{At: date("2024-09-01")})
+	assert.NoError(t, err, "FindPaths should succeed")
+	assert.Len(t, paths, 1, "A search at a date should follow the links valid then")
+	_, err = nodes.FindPaths(ctx, ids["Generics"], ids["Iterators"], domain.PathOptions{At: date("2025-01-01")})
+	assert.ErrorIs(t, err, usecase.ErrNoPath, "Expired links should not be followed")
+}
+
+func TestUpdateRelationship(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	engineID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Engine", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	carID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Car", Type: domain.Concept})
+	assert.NoError(t, err, "CreateNode should succeed")
+	ids, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: engineID, TargetIDs: []string{carID}, Type: domain.RelatedTo, Description: "Engnie"})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	created, err := nodes.GetRelationship(ctx, ids[0])
+	assert.NoError(t, err, "GetRelationship should succeed")
+
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: ids[0], Type: domain.IsPartOf, Description: "Engine", Weight: 3})
+	assert.NoError(t, err, "UpdateRelationship should succeed")
+	updated, err := nodes.GetRelationship(ctx, ids[0])
+	assert.NoError(t, err, "The id should be kept")
+	assert.Equal(t, domain.IsPartOf, updated.Type)
+	assert.Equal(t, "Engine", updated.Description)
+	assert.Equal(t, 3.0, updated.Weight)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt, "The creation time should be kept")
+	assert.Equal(t, engineID, updated.SourceID, "The endpoints should be kept")
+
+	rels, err := nodes.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 2, "Gaining an inverse should create the inverse edge")
+	assert.Empty(t, mustCheckInverses(t, nodes), "The inverse edge should match")
+
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: ids[0], Type: domain.HasPart, Description: "Engine"})
+	assert.NoError(t, err, "UpdateRelationship should succeed")
+	rels, err = nodes.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 2, "Switching between inverse types should update the inverse edge")
+	assert.Empty(t, mustCheckInverses(t, nodes), "The inverse edge should match")
+
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: ids[0], Type: domain.References})
+	assert.NoError(t, err, "UpdateRelationship should succeed")
+	rels, err = nodes.ListRelationships(ctx, "")
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Len(t, rels, 1, "Losing the inverse should delete the inverse edge")
+
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: ids[0], Type: "CITES"})
+	assert.ErrorIs(t, err, usecase.ErrUnknownRelationType, "Unknown types should be rejected")
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: "missing", Type: domain.RelatedTo})
+	assert.ErrorIs(t, err, memory.ErrNotFound, "Missing relationships should be reported")
+}
+
+func mustCheckInverses(t *testing.T, nodes *usecase.NodeUseCase) []usecase.InverseMismatch {
+	mismatches, err := nodes.CheckInverses(context.Background())
+	assert.NoError(t, err, "CheckInverses should succeed")
+	return mismatches
+}
+
+func TestFindPaths(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Go", "Channels", "Goroutines", "Concurrency"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept, Tags: []string{"go"}})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	for _, link := range [][2]string{{"Go", "Channels"}, {"Goroutines", "Go"}, {"Concurrency", "Channels"}} {
+		_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[link[0]], TargetIDs: []string{ids[link[1]]}, Type: domain.RelatedTo})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+	_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Goroutines"], TargetIDs: []string{ids["Concurrency"]}, Type: domain.References})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	paths, err := nodes.FindPaths(ctx, ids["Goroutines"], ids["Concurrency"], domain.PathOptions{})
+	assert.NoError(t, err, "FindPaths should succeed")
+	assert.Equal(t, []string{ids["Goroutines"], ids["Concurrency"]}, paths[0].NodeIDs, "The direct link should be the shortest path")
+
+	paths, err = nodes.FindPaths(ctx, ids["Goroutines"], ids["Concurrency"], domain.PathOptions{RelationTypes: []domain.RelationType{domain.RelatedTo}, All: true})
+	assert.NoError(t, err, "FindPaths should succeed")
+	assert.Len(t, paths, 1)
+	assert.Equal(t, []string{ids["Goroutines"], ids["Go"], ids["Channels"], ids["Concurrency"]}, paths[0].NodeIDs,
+		"The type filter should skip the direct link and shared tags should not connect nodes")
+
+	_, err = nodes.FindPaths(ctx, ids["Goroutines"], ids["Concurrency"], domain.PathOptions{RelationTypes: []domain.RelationType{domain.RelatedTo}, MaxDepth: 2})
+	assert.ErrorIs(t, err, usecase.ErrNoPath, "Paths longer than the max depth should not be found")
+	_, err = nodes.FindPaths(ctx, ids["Go"], ids["Channels"], domain.PathOptions{RelationTypes: []domain.RelationType{"CITES"}})
+	assert.ErrorIs(t, err, usecase.ErrUnknownRelationType, "Unknown types should be rejected")
+	_, err = nodes.FindPaths(ctx, ids["Go"], ids["Channels"], domain.PathOptions{MaxDepth: -1})
+	assert.ErrorIs(t, err, usecase.ErrInvalidPathOptions)
+}
+
+func TestGetNeighborhood(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Go", "Channels", "Select", "Goroutines", "Rust"} {
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: domain.Concept})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	for _, link := range [][2]string{{"Go", "Channels"}, {"Channels", "Select"}, {"Goroutines", "Go"}} {
+		_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids[link[0]], TargetIDs: []string{ids[link[1]]}, Type: domain.References})
+		assert.NoError(t, err, "CreateRelationship should succeed")
+	}
+	_, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Go"], TargetIDs: []string{ids["Rust"]}, Type: domain.RelatedTo})
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	titles := func(n *domain.Neighborhood) []string {
+		var list []string
+		for _, node := range n.Nodes {
+			list = append(list, node.Title)
+		}
+		return list
+	}
+
+	n, err := nodes.GetNeighborhood(ctx, ids["Go"], 1, domain.NeighborhoodFilter{})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Equal(t, []string{"Go", "Channels", "Goroutines", "Rust"}, titles(n), "The start node should come first")
+	assert.Len(t, n.Relationships, 3, "Only relationships among the returned nodes should be included")
+
+	n, err = nodes.GetNeighborhood(ctx, ids["Go"], 2, domain.NeighborhoodFilter{RelationTypes: []domain.RelationType{domain.References}, Direction: domain.DirectionOutgoing})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Equal(t, []string{"Go", "Channels", "Select"}, titles(n), "Only outgoing links of the chosen types should be followed")
+	assert.Len(t, n.Relationships, 2)
+
+	n, err = nodes.GetNeighborhood(ctx, ids["Go"], 2, domain.NeighborhoodFilter{Direction: domain.DirectionIncoming})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Equal(t, []string{"Go", "Goroutines"}, titles(n), "Only incoming links should be followed")
+
+	n, err = nodes.GetNeighborhood(ctx, ids["Rust"], 0, domain.NeighborhoodFilter{})
+	assert.NoError(t, err, "GetNeighborhood should succeed")
+	assert.Equal(t, []string{"Rust"}, titles(n))
+	assert.Empty(t, n.Relationships)
+
+	_, err = nodes.GetNeighborhood(ctx, ids["Go"], -1, domain.NeighborhoodFilter{})
+	assert.ErrorIs(t, err, usecase.ErrInvalidPathOptions)
+	_, err = nodes.GetNeighborhood(ctx, ids["Go"], domain.MaxNeighborhoodDepth+1, domain.NeighborhoodFilter{})
+	assert.ErrorIs(t, err, usecase.ErrInvalidPathOptions, "Depths above the limit should be rejected")
+	_, err = nodes.GetNeighborhood(ctx, ids["Go"], 1, domain.NeighborhoodFilter{Direction: "SIDEWAYS"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidPathOptions)
+	_, err = nodes.GetNeighborhood(ctx, ids["Go"], 1, domain.NeighborhoodFilter{RelationTypes: []domain.RelationType{"CITES"}})
+	assert.ErrorIs(t, err, usecase.ErrUnknownRelationType, "Unknown types should be rejected")
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/graph"
+)
+
+var (
+	ErrInvalidRule   = errors.New("invalid relationship rule")
+	ErrRuleViolation = errors.New("relationship rule violated")
+)
+
+// RuleError reports a relationship that breaks a rule of its type. Path holds the titles
+// of the nodes involved; for a cycle it starts and ends at the same node.
+type RuleError struct {
+	Type domain.RelationType
+	Rule string
+	Path []string
+}
+
+func (e *RuleError) Error() string {
+	msg := fmt.Sprintf("%s %s", e.Type, e.Rule)
+	if len(e.Path) > 0 {
+		msg += ": " + strings.Join(e.Path, " -> ")
+	}
+	return msg
+}
+
+func (e *RuleError) Unwrap() error {
+	return ErrRuleViolation
+}
+
+// validateRules checks that the rules of def are consistent and name known node types.
+func validateRules(registry *domain.TypeRegistry, def domain.RelationTypeDef) error {
+	rules := def.Rules
+	if rules.MaxOutDegree < 0 {
+		return fmt.Errorf("%w: max out-degree must not be negative", ErrInvalidRule)
+	}
+	if rules.Acyclic && !def.Directed {
+		return fmt.Errorf("%w: undirected type %s cannot be acyclic", ErrInvalidRule, def.Name)
+	}
+	for _, name := range append(append([]domain.NodeType(nil), rules.SourceTypes...), rules.TargetTypes...) {
+		if _, ok := registry.NodeType(name); !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownNodeType, name)
+		}
+	}
+	return nil
+}
+
+// checkRules fails with a *RuleError if writing rel would break a rule of def, or with the
+// error of reading an endpoint whose type a rule restricts, e.g. a missing one. rel may
+// have several targets. If rel.ID is set, rel replaces the stored relationship with that
+// id, which is then ignored by the degree and cycle checks.
+func checkRules(ctx context.Context, tx Tx, def domain.RelationTypeDef, rel *domain.Relationship) error {
+	rules := def.Rules
+	violation := func(rule string, nodeIDs ...string) error {
+		path := make([]string, len(nodeIDs))
+		for i, id := range nodeIDs {
+			path[i] = nodeTitle(ctx, tx, id)
+		}
+		return &RuleError{Type: def.Name, Rule: rule, Path: path}
+	}
+
+	if rules.NoSelfLoops || rules.Acyclic {
+		for _, targetID := range rel.TargetIDs {
+			if targetID == rel.SourceID {
+				return violation("must not link a node to itself", rel.SourceID, targetID)
+			}
+		}
+	}
+
+	if len(rules.SourceTypes) > 0 {
+		source, err := tx.GetNodeByID(ctx, rel.SourceID)
+		if err != nil {
+			return err
+		}
+		if !containsNodeType(rules.SourceTypes, source.Type) {
+			return violation(fmt.Sprintf("cannot start at a %s node", source.Type), rel.SourceID)
+		}
+	}
+	if len(rules.TargetTypes) > 0 {
+		for _, targetID := range rel.TargetIDs {
+			target, err := tx.GetNodeByID(ctx, targetID)
+			if err != nil {
+				return err
+			}
+			if !containsNodeType(rules.TargetTypes, target.Type) {
+				return violation(fmt.Sprintf("cannot end at a %s node", target.Type), rel.SourceID, targetID)
+			}
+		}
+	}
+
+	if rules.MaxOutDegree == 0 && !rules.Acyclic {
+		return nil
+	}
+	// The cycle check needs every link of the type, the degree check only those of the source.
+	nodeID := rel.SourceID
+	if rules.Acyclic {
+		nodeID = ""
+	}
+	all, err := tx.ListRelationships(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	var existing []*domain.Relationship
+	outDegree := 0
+	for _, r := range all {
+		if r.Type != def.Name || r.ID == rel.ID {
+			continue
+		}
+		existing = append(existing, r)
+		if r.SourceID == rel.SourceID {
+			outDegree++
+		}
+	}
+
+	if rules.MaxOutDegree > 0 && outDegree+len(rel.TargetIDs) > rules.MaxOutDegree {
+		return violation(fmt.Sprintf("allows at most %d links from a node", rules.MaxOutDegree), rel.SourceID)
+	}
+
+	if rules.Acyclic {
+		g := graph.NewDirected(existing)
+		for _, targetID := range rel.TargetIDs {
+			// The new link closes a cycle if its source can already be reached from its target.
+			if path, ok := g.ShortestPath(targetID, rel.SourceID); ok {
+				return violation("must not form a cycle", append([]string{rel.SourceID}, path.NodeIDs...)...)
+			}
+			g.AddEdge(graph.Edge{From: rel.SourceID, To: targetID, Weight: domain.DefaultWeight})
+		}
+	}
+	return nil
+}
+
+// nodeTitle returns the title of a node for messages, or its id if it cannot be read.
+func nodeTitle(ctx context.Context, tx Tx, id string) string {
+	node, err := tx.GetNodeByID(ctx, id)
+	if err != nil || node.Title == "" {
+		return id
+	}
+	return node.Title
+}
+
+func containsNodeType(types []domain.NodeType, t domain.NodeType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
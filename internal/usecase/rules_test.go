@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestRelationshipRules(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	types := usecase.NewTypeUseCase(repo)
+
+	ids := make(map[string]string)
+	for _, title := range []string{"Basics", "Functions", "Closures", "Notes"} {
+		nodeType := domain.Concept
+		if title == "Notes" {
+			nodeType = domain.Note
+		}
+		id, err := nodes.CreateNode(ctx, &domain.Node{Title: title, Type: nodeType})
+		assert.NoError(t, err, "CreateNode should succeed")
+		ids[title] = id
+	}
+	link := func(relType domain.RelationType, source string, targets ...string) ([]string, error) {
+		rel := &domain.Relationship{SourceID: ids[source], Type: relType}
+		for _, target := range targets {
+			rel.TargetIDs = append(rel.TargetIDs, ids[target])
+		}
+		return nodes.CreateRelationship(ctx, rel)
+	}
+
+	_, err := link(domain.DependsOn, "Functions", "Functions")
+	assert.ErrorIs(t, err, usecase.ErrRuleViolation, "Self-loops should be rejected")
+	_, err = link(domain.DependsOn, "Closures", "Functions")
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	_, err = link(domain.DependsOn, "Functions", "Basics")
+	assert.NoError(t, err, "CreateRelationship should succeed")
+
+	_, err = link(domain.DependsOn, "Basics", "Notes", "Closures")
+	var ruleErr *usecase.RuleError
+	assert.True(t, errors.As(err, &ruleErr), "Cycles should be rejected with a RuleError")
+	assert.Equal(t, []string{"Basics", "Closures", "Functions", "Basics"}, ruleErr.Path, "The error should show the cycle")
+	assert.EqualError(t, err, "DEPENDS_ON must not form a cycle: Basics -> Closures -> Functions -> Basics")
+	rels, err := nodes.ListRelationships(ctx, ids["Notes"])
+	assert.NoError(t, err, "ListRelationships should succeed")
+	assert.Empty(t, rels, "A rejected relationship should not be written in part")
+
+	_, err = link(domain.RelatedTo, "Basics", "Closures")
+	assert.NoError(t, err, "Types without rules should allow anything")
+	relIDs, err := link(domain.RelatedTo, "Functions", "Basics")
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: relIDs[0], Type: domain.IsPrecededBy})
+	assert.NoError(t, err, "Updates that keep the type acyclic should succeed")
+	err = nodes.UpdateRelationship(ctx, &domain.Relationship{ID: relIDs[0], Type: domain.DependsOn})
+	assert.NoError(t, err, "The updated relationship should not conflict with itself")
+
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "EXPLAINS", Directed: true, Rules: domain.RelationRules{MaxOutDegree: -1}})
+	assert.ErrorIs(t, err, usecase.ErrInvalidRule, "Negative degrees should be rejected")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "EXPLAINS", Rules: domain.RelationRules{Acyclic: true}})
+	assert.ErrorIs(t, err, usecase.ErrInvalidRule, "Undirected types cannot be acyclic")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "EXPLAINS", Directed: true, Rules: domain.RelationRules{SourceTypes: []domain.NodeType{"PERSON"}}})
+	assert.ErrorIs(t, err, usecase.ErrUnknownNodeType, "Rules should name known node types")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "EXPLAINS", Directed: true, Rules: domain.RelationRules{
+		MaxOutDegree: 1,
+		SourceTypes:  []domain.NodeType{domain.Note},
+		TargetTypes:  []domain.NodeType{domain.Concept},
+	}})
+	assert.NoError(t, err, "SaveRelationType should succeed")
+
+	_, err = link("EXPLAINS", "Basics", "Functions")
+	assert.EqualError(t, err, "EXPLAINS cannot start at a CONCEPT node: Basics")
+	_, err = link("EXPLAINS", "Notes", "Functions", "Closures")
+	assert.EqualError(t, err, "EXPLAINS allows at most 1 links from a node: Notes")
+	_, err = link("EXPLAINS", "Notes", "Functions")
+	assert.NoError(t, err, "CreateRelationship should succeed")
+	_, err = link("EXPLAINS", "Notes", "Closures")
+	assert.ErrorIs(t, err, usecase.ErrRuleViolation, "Existing links should count towards the degree")
+
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: "missing", TargetIDs: []string{ids["Closures"]}, Type: "EXPLAINS"})
+	assert.ErrorIs(t, err, memory.ErrNotFound, "A missing source should fail the type rule")
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: ids["Notes"], TargetIDs: []string{"missing"}, Type: "EXPLAINS"})
+	assert.ErrorIs(t, err, memory.ErrNotFound, "A missing target should fail the type rule")
+}
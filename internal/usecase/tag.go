@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+var (
+	ErrEmptyTagName   = errors.New("tag name must not be empty")
+	ErrTagExists      = errors.New("tag already exists")
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrTagHasChildren = errors.New("tag has child tags")
+	ErrTagCycle       = errors.New("tag cannot be moved below itself")
+)
+
+type TagUseCase struct {
+	repo TagRepository
+}
+
+func NewTagUseCase(repo TagRepository) *TagUseCase {
+	return &TagUseCase{
+		repo: repo,
+	}
+}
+
+func (uc *TagUseCase) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	return uc.repo.ListTags(ctx)
+}
+
+// RenameTag renames oldName to newName and cascades to its descendants, so renaming
+// "lang" to "languages" turns "lang/go" into "languages/go". Renaming onto an existing
+// tag is rejected, use MergeTags to combine two tags.
+func (uc *TagUseCase) RenameTag(ctx context.Context, oldName, newName string) error {
+	oldName, newName = domain.NormalizeTag(oldName), domain.NormalizeTag(newName)
+	if oldName == "" || newName == "" {
+		return ErrEmptyTagName
+	}
+	if oldName == newName {
+		return nil
+	}
+	if domain.IsTagWithin(newName, oldName) {
+		return fmt.Errorf("%w: %s", ErrTagCycle, newName)
+	}
+
+	tags, err := uc.repo.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	if findTag(tags, oldName) == nil {
+		return fmt.Errorf("%w: %s", ErrTagNotFound, oldName)
+	}
+	for _, tag := range tags {
+		if !domain.IsTagWithin(tag.Name, oldName) {
+			continue
+		}
+		if renamed := domain.RebaseTag(tag.Name, oldName, newName); findTag(tags, renamed) != nil {
+			return fmt.Errorf("%w: %s", ErrTagExists, renamed)
+		}
+	}
+	return uc.repo.RenameTag(ctx, oldName, newName)
+}
+
+// MergeTags retags every node using one of sources with target and deletes the sources.
+// target is created if it does not exist yet. Sources with child tags are rejected,
+// their children have to be renamed or merged first.
+func (uc *TagUseCase) MergeTags(ctx context.Context, sources []string, target string) error {
+	target = domain.NormalizeTag(target)
+	if target == "" {
+		return ErrEmptyTagName
+	}
+
+	var cleaned []string
+	for _, s := range sources {
+		s = domain.NormalizeTag(s)
+		if s != "" && s != target {
+			cleaned = append(cleaned, s)
+		}
+	}
+	if len(cleaned) == 0 {
+		return nil
+	}
+
+	tags, err := uc.repo.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		for _, source := range cleaned {
+			if tag.Parent == source {
+				return fmt.Errorf("%w: %s", ErrTagHasChildren, source)
+			}
+		}
+	}
+	return uc.repo.MergeTags(ctx, cleaned, target)
+}
+
+func (uc *TagUseCase) DeleteOrphanTags(ctx context.Context) ([]string, error) {
+	return uc.repo.DeleteOrphanTags(ctx)
+}
+
+func (uc *TagUseCase) NodesByTag(ctx context.Context, name string) ([]*domain.Node, error) {
+	return uc.repo.NodesByTag(ctx, domain.NormalizeTag(name))
+}
+
+func findTag(tags []domain.Tag, name string) *domain.Tag {
+	for i := range tags {
+		if tags[i].Name == name {
+			return &tags[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+type TagRepository interface {
+	// ListTags returns every tag of the workspace ordered by name, including unused ones
+	// and the parents of hierarchical tags.
+	ListTags(ctx context.Context) ([]domain.Tag, error)
+	// RenameTag renames a tag and all of its descendants on every node that uses them.
+	RenameTag(ctx context.Context, oldName, newName string) error
+	// MergeTags moves every node tagged with one of sources to target and removes sources.
+	MergeTags(ctx context.Context, sources []string, target string) error
+	// DeleteOrphanTags removes tags that no node uses, directly or through a descendant,
+	// and returns their names.
+	DeleteOrphanTags(ctx context.Context) ([]string, error)
+	// NodesByTag returns the nodes tagged with name or one of its descendants.
+	NodesByTag(ctx context.Context, name string) ([]*domain.Node, error)
+}
@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestTagUseCase(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	tags := usecase.NewTagUseCase(repo)
+
+	goID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Goroutines", Type: domain.Concept, Tags: []string{"golang", "concurrency"}})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = nodes.CreateNode(ctx, &domain.Node{Title: "Channels", Type: domain.Concept, Tags: []string{"go", "concurrency"}})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	list, err := tags.ListTags(ctx)
+	assert.NoError(t, err, "ListTags should succeed")
+	assert.Equal(t, []domain.Tag{{Name: "concurrency", Count: 2}, {Name: "go", Count: 1}, {Name: "golang", Count: 1}}, list,
+		"Tags should be listed by name with usage counts")
+
+	err = tags.RenameTag(ctx, "golang", "go")
+	assert.ErrorIs(t, err, usecase.ErrTagExists, "Renaming onto an existing tag should be rejected")
+	err = tags.RenameTag(ctx, "missing", "other")
+	assert.ErrorIs(t, err, usecase.ErrTagNotFound, "Renaming a missing tag should be rejected")
+
+	err = tags.MergeTags(ctx, []string{"golang"}, "go")
+	assert.NoError(t, err, "MergeTags should succeed")
+	tagged, err := tags.NodesByTag(ctx, "go")
+	assert.NoError(t, err, "NodesByTag should succeed")
+	assert.Len(t, tagged, 2, "Both nodes should carry the merged tag")
+
+	err = tags.RenameTag(ctx, "concurrency", "parallelism")
+	assert.NoError(t, err, "RenameTag should succeed")
+	node, err := nodes.GetNode(ctx, goID)
+	assert.NoError(t, err, "GetNode should succeed")
+	assert.ElementsMatch(t, []string{"go", "parallelism"}, node.Tags, "Node tags should follow the rename and merge")
+
+	node.Tags = []string{"go"}
+	err = nodes.UpdateNode(ctx, node)
+	assert.NoError(t, err, "UpdateNode should succeed")
+	orphans, err := tags.DeleteOrphanTags(ctx)
+	assert.NoError(t, err, "DeleteOrphanTags should succeed")
+	assert.Empty(t, orphans, "parallelism is still used by Channels")
+}
+
+func TestHierarchicalTags(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	tags := usecase.NewTagUseCase(repo)
+
+	id, err := nodes.CreateNode(ctx, &domain.Node{Title: "Goroutines", Type: domain.Concept, Tags: []string{" lang / go /concurrency "}})
+	assert.NoError(t, err, "CreateNode should succeed")
+	_, err = nodes.CreateNode(ctx, &domain.Node{Title: "Go", Type: domain.Concept, Tags: []string{"lang/go"}})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	list, err := tags.ListTags(ctx)
+	assert.NoError(t, err, "ListTags should succeed")
+	assert.Equal(t, []domain.Tag{
+		{Name: "lang", Count: 0},
+		{Name: "lang/go", Parent: "lang", Count: 1},
+		{Name: "lang/go/concurrency", Parent: "lang/go", Count: 1},
+	}, list, "Parents of hierarchical tags should be listed")
+
+	tagged, err := tags.NodesByTag(ctx, "lang")
+	assert.NoError(t, err, "NodesByTag should succeed")
+	assert.Len(t, tagged, 2, "Searching a parent tag should include descendants")
+
+	err = tags.RenameTag(ctx, "lang", "lang/programming")
+	assert.ErrorIs(t, err, usecase.ErrTagCycle, "A tag cannot be moved below itself")
+	err = tags.MergeTags(ctx, []string{"lang/go"}, "golang")
+	assert.ErrorIs(t, err, usecase.ErrTagHasChildren, "Tags with children cannot be merged")
+
+	err = tags.RenameTag(ctx, "lang", "languages")
+	assert.NoError(t, err, "RenameTag should succeed")
+	node, err := nodes.GetNode(ctx, id)
+	assert.NoError(t, err, "GetNode should succeed")
+	assert.Equal(t, []string{"languages/go/concurrency"}, node.Tags, "Renaming a parent should cascade to children")
+
+	orphans, err := tags.DeleteOrphanTags(ctx)
+	assert.NoError(t, err, "DeleteOrphanTags should succeed")
+	assert.Empty(t, orphans, "Parents of used tags are not orphans")
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+var (
+	ErrInvalidTypeName     = errors.New("type names must be upper case letters, digits and underscores")
+	ErrInvalidColor        = errors.New("colour must be a #RRGGBB hex value")
+	ErrInvalidInverse      = errors.New("invalid inverse relationship type")
+	ErrUnknownNodeType     = errors.New("unknown node type")
+	ErrUnknownRelationType = errors.New("unknown relationship type")
+	ErrBuiltinType         = errors.New("built-in types cannot be deleted")
+	ErrTypeInUse           = errors.New("type is in use")
+)
+
+var (
+	// typeNamePattern restricts type names to valid unquoted Neo4j labels and relationship
+	// types, since the repository concatenates them into Cypher.
+	typeNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	colorPattern    = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+type TypeUseCase struct {
+	repo TypeRepository
+}
+
+func NewTypeUseCase(repo TypeRepository) *TypeUseCase {
+	return &TypeUseCase{
+		repo: repo,
+	}
+}
+
+// Registry loads the built-in and user-defined types of the workspace.
+func (uc *TypeUseCase) Registry(ctx context.Context) (*domain.TypeRegistry, error) {
+	nodeTypes, err := uc.repo.ListNodeTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	relationTypes, err := uc.repo.ListRelationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewTypeRegistry(nodeTypes, relationTypes), nil
+}
+
+// SaveNodeType creates a node type or updates the colour, icon, description and property
+// schema of an existing one. Values of removed properties stay on existing nodes until
+// the nodes are edited.
+func (uc *TypeUseCase) SaveNodeType(ctx context.Context, def domain.NodeTypeDef) error {
+	if !typeNamePattern.MatchString(string(def.Name)) {
+		return fmt.Errorf("%w: %q", ErrInvalidTypeName, def.Name)
+	}
+	if !colorPattern.MatchString(def.Color) {
+		return fmt.Errorf("%w: %q", ErrInvalidColor, def.Color)
+	}
+	if err := validatePropertyDefs(def.Properties); err != nil {
+		return err
+	}
+	return uc.repo.SaveNodeType(ctx, def)
+}
+
+// DeleteNodeType removes a user-defined node type that no node uses.
+func (uc *TypeUseCase) DeleteNodeType(ctx context.Context, name domain.NodeType) error {
+	if domain.IsBuiltinNodeType(name) {
+		return fmt.Errorf("%w: %s", ErrBuiltinType, name)
+	}
+	count, err := uc.repo.CountNodesOfType(ctx, name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d nodes of type %s", ErrTypeInUse, count, name)
+	}
+	return uc.repo.DeleteNodeType(ctx, name)
+}
+
+// SaveRelationType creates or updates a relationship type. Inverses are kept in pairs:
+// declaring B as the inverse of A also makes A the inverse of B, and drops A from the
+// type it was previously paired with. Undirected types cannot have an inverse. The rules
+// only apply to relationships written afterwards. Both sides of a pair are saved in one
+// transaction.
+func (uc *TypeUseCase) SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error {
+	if !typeNamePattern.MatchString(string(def.Name)) {
+		return fmt.Errorf("%w: %q", ErrInvalidTypeName, def.Name)
+	}
+	if def.Inverse == def.Name {
+		def.Inverse = ""
+	}
+
+	registry, err := uc.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateRules(registry, def); err != nil {
+		return err
+	}
+	var inverse domain.RelationTypeDef
+	if def.Inverse != "" {
+		if !def.Directed {
+			return fmt.Errorf("%w: undirected type %s cannot have an inverse", ErrInvalidInverse, def.Name)
+		}
+		var ok bool
+		if inverse, ok = registry.RelationType(def.Inverse); !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownRelationType, def.Inverse)
+		}
+		if !inverse.Directed {
+			return fmt.Errorf("%w: %s is undirected", ErrInvalidInverse, def.Inverse)
+		}
+		if inverse.Inverse != "" && inverse.Inverse != def.Name {
+			return fmt.Errorf("%w: %s is already the inverse of %s", ErrInvalidInverse, def.Inverse, inverse.Inverse)
+		}
+	}
+
+	return uc.repo.WithinTypeTx(ctx, func(tx TypeTx) error {
+		if err := tx.SaveRelationType(ctx, def); err != nil {
+			return err
+		}
+		if previous, ok := registry.RelationType(def.Name); ok && previous.Inverse != "" && previous.Inverse != def.Inverse {
+			if err := unpair(ctx, tx, registry, previous.Inverse, def.Name); err != nil {
+				return err
+			}
+		}
+		if def.Inverse != "" && inverse.Inverse != def.Name {
+			inverse.Inverse = def.Name
+			return tx.SaveRelationType(ctx, inverse)
+		}
+		return nil
+	})
+}
+
+// DeleteRelationType removes a user-defined relationship type that no relationship uses.
+func (uc *TypeUseCase) DeleteRelationType(ctx context.Context, name domain.RelationType) error {
+	if domain.IsBuiltinRelationType(name) {
+		return fmt.Errorf("%w: %s", ErrBuiltinType, name)
+	}
+	count, err := uc.repo.CountRelationshipsOfType(ctx, name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %d relationships of type %s", ErrTypeInUse, count, name)
+	}
+
+	registry, err := uc.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.repo.WithinTypeTx(ctx, func(tx TypeTx) error {
+		if def, ok := registry.RelationType(name); ok && def.Inverse != "" {
+			if err := unpair(ctx, tx, registry, def.Inverse, name); err != nil {
+				return err
+			}
+		}
+		return tx.DeleteRelationType(ctx, name)
+	})
+}
+
+// ValidateNode checks that the node type is defined and that the custom properties match
+// its schema. The properties of node are replaced with their canonical form.
+func (uc *TypeUseCase) ValidateNode(ctx context.Context, node *domain.Node) error {
+	registry, err := uc.Registry(ctx)
+	if err != nil {
+		return err
+	}
+	def, ok := registry.NodeType(node.Type)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownNodeType, node.Type)
+	}
+	properties, err := normalizeProperties(def, node.Properties)
+	if err != nil {
+		return err
+	}
+	node.Properties = properties
+	return nil
+}
+
+// RelationType returns the definition of a relationship type and fails if it is not defined.
+func (uc *TypeUseCase) RelationType(ctx context.Context, name domain.RelationType) (domain.RelationTypeDef, error) {
+	registry, err := uc.Registry(ctx)
+	if err != nil {
+		return domain.RelationTypeDef{}, err
+	}
+	def, ok := registry.RelationType(name)
+	if !ok {
+		return domain.RelationTypeDef{}, fmt.Errorf("%w: %q", ErrUnknownRelationType, name)
+	}
+	return def, nil
+}
+
+// unpair clears the inverse of name if it still points at from.
+func unpair(ctx context.Context, tx TypeTx, registry *domain.TypeRegistry, name, from domain.RelationType) error {
+	def, ok := registry.RelationType(name)
+	if !ok || def.Inverse != from {
+		return nil
+	}
+	def.Inverse = ""
+	return tx.SaveRelationType(ctx, def)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// TypeRepository stores the user-defined node and relationship types of a workspace.
+// Built-in types are not stored unless they were restyled.
+type TypeRepository interface {
+	// ListNodeTypes returns the stored node type definitions ordered by name.
+	ListNodeTypes(ctx context.Context) ([]domain.NodeTypeDef, error)
+	// SaveNodeType creates or replaces the definition with the same name.
+	SaveNodeType(ctx context.Context, def domain.NodeTypeDef) error
+	DeleteNodeType(ctx context.Context, name domain.NodeType) error
+	// CountNodesOfType returns the number of nodes of the given type.
+	CountNodesOfType(ctx context.Context, name domain.NodeType) (int, error)
+
+	// ListRelationTypes returns the stored relationship type definitions ordered by name.
+	ListRelationTypes(ctx context.Context) ([]domain.RelationTypeDef, error)
+	// SaveRelationType creates or replaces the definition with the same name.
+	SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error
+	DeleteRelationType(ctx context.Context, name domain.RelationType) error
+	// CountRelationshipsOfType returns the number of relationships of the given type.
+	CountRelationshipsOfType(ctx context.Context, name domain.RelationType) (int, error)
+
+	// WithinTypeTx runs fn in a single transaction. The transaction is committed when fn
+	// returns nil and rolled back otherwise. fn may be retried on transient errors,
+	// so it must not have side effects outside of tx.
+	WithinTypeTx(ctx context.Context, fn func(tx TypeTx) error) error
+}
+
+// TypeTx holds the relationship type writes that have to commit together, such as the
+// two sides of an inverse pair.
+type TypeTx interface {
+	SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error
+	DeleteRelationType(ctx context.Context, name domain.RelationType) error
+}
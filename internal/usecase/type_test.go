@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestTypeRegistry(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	types := usecase.NewTypeUseCase(repo)
+
+	_, err := nodes.CreateNode(ctx, &domain.Node{Title: "Alan Turing", Type: "PERSON"})
+	assert.ErrorIs(t, err, usecase.ErrUnknownNodeType, "Nodes of undefined types should be rejected")
+
+	err = types.SaveNodeType(ctx, domain.NodeTypeDef{Name: "Person", Color: "#FF0000"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidTypeName, "Type names must be upper case")
+	err = types.SaveNodeType(ctx, domain.NodeTypeDef{Name: "PERSON", Color: "red"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidColor, "Colours must be hex values")
+	err = types.SaveNodeType(ctx, domain.NodeTypeDef{Name: "PERSON", Color: "#FF0000", Icon: "account"})
+	assert.NoError(t, err, "SaveNodeType should succeed")
+
+	personID, err := nodes.CreateNode(ctx, &domain.Node{Title: "Alan Turing", Type: "PERSON"})
+	assert.NoError(t, err, "Nodes of user-defined types should be accepted")
+	paperID, err := nodes.CreateNode(ctx, &domain.Node{Title: "On Computable Numbers", Type: domain.Reference})
+	assert.NoError(t, err, "CreateNode should succeed")
+
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "AUTHORED", Inverse: "AUTHORED_BY", Directed: true})
+	assert.ErrorIs(t, err, usecase.ErrUnknownRelationType, "The inverse has to be defined first")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "AUTHORED_BY", Directed: true})
+	assert.NoError(t, err, "SaveRelationType should succeed")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "AUTHORED", Inverse: "AUTHORED_BY", Directed: true})
+	assert.NoError(t, err, "SaveRelationType should succeed")
+	err = types.SaveRelationType(ctx, domain.RelationTypeDef{Name: "KNOWS", Inverse: "AUTHORED"})
+	assert.ErrorIs(t, err, usecase.ErrInvalidInverse, "Undirected types cannot have an inverse")
+
+	registry, err := types.Registry(ctx)
+	assert.NoError(t, err, "Registry should succeed")
+	assert.Equal(t, []string{"CONCEPT", "NOTE", "REFERENCE", "PERSON"}, registry.NodeTypeNames(),
+		"Built-in types should come first")
+	inverse, _ := registry.RelationType("AUTHORED_BY")
+	assert.Equal(t, domain.RelationType("AUTHORED"), inverse.Inverse, "Inverses should be kept in pairs")
+
+	_, err = nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: personID, TargetIDs: []string{paperID}, Type: "CITES"})
+	assert.ErrorIs(t, err, usecase.ErrUnknownRelationType, "Relationships of undefined types should be rejected")
+	relIDs, err := nodes.CreateRelationship(ctx, &domain.Relationship{SourceID: personID, TargetIDs: []string{paperID}, Type: "AUTHORED"})
+	assert.NoError(t, err, "Relationships of user-defined types should be accepted")
+
+	assert.ErrorIs(t, types.DeleteNodeType(ctx, domain.Note), usecase.ErrBuiltinType, "Built-in types cannot be deleted")
+	assert.ErrorIs(t, types.DeleteNodeType(ctx, "PERSON"), usecase.ErrTypeInUse, "Used types cannot be deleted")
+	assert.ErrorIs(t, types.DeleteRelationType(ctx, "AUTHORED"), usecase.ErrTypeInUse, "Used types cannot be deleted")
+	assert.ErrorIs(t, types.DeleteRelationType(ctx, "AUTHORED_BY"), usecase.ErrTypeInUse, "The inverse edge uses the inverse type")
+
+	err = nodes.DeleteRelationship(ctx, relIDs[0])
+	assert.NoError(t, err, "DeleteRelationship should succeed")
+	err = types.DeleteRelationType(ctx, "AUTHORED_BY")
+	assert.NoError(t, err, "Unused types can be deleted")
+	registry, err = types.Registry(ctx)
+	assert.NoError(t, err, "Registry should succeed")
+	authored, _ := registry.RelationType("AUTHORED")
+	assert.Empty(t, authored.Inverse, "Deleting a type should clear it as inverse")
+}
+
+// failingTypes fails every save of the relationship type failOn.
+type failingTypes struct {
+	*memory.NodeRepository
+	failOn domain.RelationType
+}
+
+func (r failingTypes) WithinTypeTx(ctx context.Context, fn func(tx usecase.TypeTx) error) error {
+	return r.NodeRepository.WithinTypeTx(ctx, func(tx usecase.TypeTx) error {
+		return fn(failingTypeTx{TypeTx: tx, failOn: r.failOn})
+	})
+}
+
+type failingTypeTx struct {
+	usecase.TypeTx
+	failOn domain.RelationType
+}
+
+func (tx failingTypeTx) SaveRelationType(ctx context.Context, def domain.RelationTypeDef) error {
+	if def.Name == tx.failOn {
+		return errors.New("save failed")
+	}
+	return tx.TypeTx.SaveRelationType(ctx, def)
+}
+
+func TestSaveRelationTypeIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	types := usecase.NewTypeUseCase(repo)
+	for _, def := range []domain.RelationTypeDef{
+		{Name: "AUTHORED_BY", Directed: true},
+		{Name: "WRITTEN_BY", Directed: true},
+		{Name: "AUTHORED", Inverse: "AUTHORED_BY", Directed: true},
+	} {
+		assert.NoError(t, types.SaveRelationType(ctx, def), "SaveRelationType should succeed")
+	}
+
+	failing := usecase.NewTypeUseCase(failingTypes{NodeRepository: repo, failOn: "WRITTEN_BY"})
+	err := failing.SaveRelationType(ctx, domain.RelationTypeDef{Name: "AUTHORED", Inverse: "WRITTEN_BY", Directed: true})
+	assert.Error(t, err, "A failed write should fail SaveRelationType")
+
+	registry, err := types.Registry(ctx)
+	assert.NoError(t, err, "Registry should succeed")
+	authored, _ := registry.RelationType("AUTHORED")
+	assert.Equal(t, domain.RelationType("AUTHORED_BY"), authored.Inverse, "A failed save should change no type")
+	authoredBy, _ := registry.RelationType("AUTHORED_BY")
+	assert.Equal(t, domain.RelationType("AUTHORED"), authoredBy.Inverse, "A failed save should keep the old pair")
+}
+
+func TestCustomProperties(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	nodes := usecase.NewNodeUseCase(repo, repo)
+	types := usecase.NewTypeUseCase(repo)
+
+	err := types.SaveNodeType(ctx, domain.NodeTypeDef{Name: "TASK", Color: "#00FF00", Properties: []domain.PropertyDef{
+		{Name: "status", Kind: domain.PropertyEnum},
+	}})
+	assert.ErrorIs(t, err, usecase.ErrInvalidPropertyDef, "Enum properties need options")
+	err = types.SaveNodeType(ctx, domain.NodeTypeDef{Name: "TASK", Color: "#00FF00", Properties: []domain.PropertyDef{
+		{Name: "status", Kind: domain.PropertyEnum, Required: true, Options: []string{"todo", "done"}},
+		{Name: "priority", Kind: domain.PropertyNumber},
+		{Name: "due", Kind: domain.PropertyDate},
+		{Name: "blocked", Kind: domain.PropertyBool},
+		{Name: "link", Kind: domain.PropertyURL},
+	}})
+	assert.NoError(t, err, "SaveNodeType should succeed")
+
+	task := &domain.Node{Title: "Write docs", Type: "TASK", Properties: map[string]string{"priority": "2"}}
+	_, err = nodes.CreateNode(ctx, task)
+	assert.ErrorIs(t, err, usecase.ErrMissingProperty, "Required properties must be set")
+
+	for name, value := range map[string]string{"status": "later", "priority": "high", "due": "31.12.2024", "blocked": "maybe", "link": "docs"} {
+		task.Properties = map[string]string{"status": "todo", name: value}
+		_, err = nodes.CreateNode(ctx, task)
+		assert.ErrorIs(t, err, usecase.ErrInvalidProperty, "Invalid %s should be rejected", name)
+	}
+	task.Properties = map[string]string{"status": "todo", "owner": "me"}
+	_, err = nodes.CreateNode(ctx, task)
+	assert.ErrorIs(t, err, usecase.ErrUnknownProperty, "Undeclared properties should be rejected")
+
+	task.Properties = map[string]string{"status": "todo", "priority": "2.50", "due": "2024-12-31", "blocked": "1", "link": "https://example.com/docs"}
+	id, err := nodes.CreateNode(ctx, task)
+	assert.NoError(t, err, "Valid properties should be accepted")
+	stored, err := nodes.GetNode(ctx, id)
+	assert.NoError(t, err, "GetNode should succeed")
+	assert.Equal(t, map[string]string{
+		"status": "todo", "priority": "2.5", "due": "2024-12-31", "blocked": "true", "link": "https://example.com/docs",
+	}, stored.Properties, "Values should be stored in canonical form")
+
+	found, err := nodes.SearchNodes(ctx, "status:TODO", "Property")
+	assert.NoError(t, err, "SearchNodes should succeed")
+	assert.Len(t, found, 1, "Nodes should be found by a single property")
+	found, err = nodes.SearchNodes(ctx, "example.com", "All")
+	assert.NoError(t, err, "SearchNodes should succeed")
+	assert.Len(t, found, 1, "Searching all fields should include properties")
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+var (
+	ErrEmptyViewName = errors.New("view name must not be empty")
+	ErrViewNotFound  = errors.New("view not found")
+	ErrInvalidZoom   = errors.New("zoom must be positive")
+)
+
+type ViewUseCase struct {
+	repo ViewRepository
+}
+
+func NewViewUseCase(repo ViewRepository) *ViewUseCase {
+	return &ViewUseCase{
+		repo: repo,
+	}
+}
+
+// ListViews returns the saved views ordered by name, without the default view that
+// holds the positions arranged outside of a saved view.
+func (uc *ViewUseCase) ListViews(ctx context.Context) ([]domain.View, error) {
+	views, err := uc.repo.ListViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+	saved := views[:0]
+	for _, v := range views {
+		if v.Name != domain.DefaultView {
+			saved = append(saved, v)
+		}
+	}
+	return saved, nil
+}
+
+// GetView returns the named view. The default view always exists; it is empty until a
+// node is moved outside of a saved view.
+func (uc *ViewUseCase) GetView(ctx context.Context, name string) (*domain.View, error) {
+	name = strings.TrimSpace(name)
+	view, err := uc.repo.GetView(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil {
+		if name != domain.DefaultView {
+			return nil, fmt.Errorf("%w: %s", ErrViewNotFound, name)
+		}
+		view = &domain.View{Name: domain.DefaultView, Zoom: domain.DefaultZoom}
+	}
+	if view.Positions == nil {
+		view.Positions = make(map[string]domain.Position)
+	}
+	return view, nil
+}
+
+// SaveView creates or replaces the named view. A zero zoom is saved as DefaultZoom.
+func (uc *ViewUseCase) SaveView(ctx context.Context, view domain.View) error {
+	view.Name = strings.TrimSpace(view.Name)
+	if view.Name == "" {
+		return ErrEmptyViewName
+	}
+	if view.Zoom < 0 {
+		return fmt.Errorf("%w: %g", ErrInvalidZoom, view.Zoom)
+	}
+	if view.Zoom == 0 {
+		view.Zoom = domain.DefaultZoom
+	}
+	view.Query = strings.TrimSpace(view.Query)
+	view.UpdatedAt = time.Now().UTC()
+	return uc.repo.SaveView(ctx, view)
+}
+
+// DeleteView removes a saved view. Deleting the default view forgets the positions
+// arranged outside of saved views.
+func (uc *ViewUseCase) DeleteView(ctx context.Context, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyViewName
+	}
+	return uc.repo.DeleteView(ctx, name)
+}
+
+// MoveNode records where the user dropped a node in the named view, creating the
+// default view on first use.
+func (uc *ViewUseCase) MoveNode(ctx context.Context, name, nodeID string, pos domain.Position) error {
+	view, err := uc.GetView(ctx, name)
+	if err != nil {
+		return err
+	}
+	view.Positions[nodeID] = pos
+	return uc.SaveView(ctx, *view)
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+)
+
+// ViewRepository stores the saved views of a workspace.
+type ViewRepository interface {
+	// ListViews returns the views ordered by name.
+	ListViews(ctx context.Context) ([]domain.View, error)
+	// GetView returns the named view, or nil if there is none.
+	GetView(ctx context.Context, name string) (*domain.View, error)
+	// SaveView creates or replaces the view with the same name.
+	SaveView(ctx context.Context, view domain.View) error
+	DeleteView(ctx context.Context, name string) error
+}
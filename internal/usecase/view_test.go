@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/AndrivA89/neo4j-go-playground/internal/domain"
+	"github.com/AndrivA89/neo4j-go-playground/internal/repository/memory"
+	"github.com/AndrivA89/neo4j-go-playground/internal/usecase"
+)
+
+func TestViews(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewNodeRepository()
+	views := usecase.NewViewUseCase(repo)
+
+	view, err := views.GetView(ctx, domain.DefaultView)
+	assert.NoError(t, err, "The default view should always exist")
+	assert.Empty(t, view.Positions)
+	_, err = views.GetView(ctx, "missing")
+	assert.ErrorIs(t, err, usecase.ErrViewNotFound)
+
+	err = views.MoveNode(ctx, domain.DefaultView, "a", domain.Position{X: 10, Y: 20})
+	assert.NoError(t, err, "MoveNode should succeed")
+	err = views.MoveNode(ctx, domain.DefaultView, "a", domain.Position{X: 30, Y: 40})
+	assert.NoError(t, err, "MoveNode should succeed")
+	view, err = views.GetView(ctx, domain.DefaultView)
+	assert.NoError(t, err, "GetView should succeed")
+	assert.Equal(t, map[string]domain.Position{"a": {X: 30, Y: 40}}, view.Positions, "The last drop should win")
+	assert.Equal(t, domain.DefaultZoom, view.Zoom)
+
+	err = views.SaveView(ctx, domain.View{Name: "  "})
+	assert.ErrorIs(t, err, usecase.ErrEmptyViewName)
+	err = views.SaveView(ctx, domain.View{Name: "reading", Zoom: -1})
+	assert.ErrorIs(t, err, usecase.ErrInvalidZoom)
+	err = views.SaveView(ctx, domain.View{Name: " reading ", Query: "go", Criteria: "Tag", Positions: view.Positions, Zoom: 2})
+	assert.NoError(t, err, "SaveView should succeed")
+	err = views.MoveNode(ctx, "reading", "b", domain.Position{X: 1, Y: 2})
+	assert.NoError(t, err, "MoveNode should succeed")
+
+	saved, err := views.ListViews(ctx)
+	assert.NoError(t, err, "ListViews should succeed")
+	if assert.Len(t, saved, 1, "The default view should not be listed") {
+		assert.Equal(t, "reading", saved[0].Name, "Names should be trimmed")
+		assert.Equal(t, 2.0, saved[0].Zoom)
+		assert.Len(t, saved[0].Positions, 2)
+	}
+	view, err = views.GetView(ctx, domain.DefaultView)
+	assert.NoError(t, err, "GetView should succeed")
+	assert.Len(t, view.Positions, 1, "Views should not share positions")
+
+	other := usecase.NewViewUseCase(repo.WithWorkspace(domain.Workspace{Name: "other"}))
+	saved, err = other.ListViews(ctx)
+	assert.NoError(t, err, "ListViews should succeed")
+	assert.Empty(t, saved, "Views should be scoped to their workspace")
+
+	assert.NoError(t, views.DeleteView(ctx, "reading"), "DeleteView should succeed")
+	_, err = views.GetView(ctx, "reading")
+	assert.ErrorIs(t, err, usecase.ErrViewNotFound)
+}